@@ -16,6 +16,17 @@
 extern "C" {
 #endif
 
+/**
+ * @brief String styles accepted by uuid_to_string_styled
+ */
+typedef enum {
+    UUID_STYLE_CANONICAL = 0, /**< xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx (36 characters) */
+    UUID_STYLE_BRACED = 1,    /**< {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx} (38 characters) */
+    UUID_STYLE_SIMPLE = 2,    /**< xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx (32 characters) */
+    UUID_STYLE_URN = 3,       /**< urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx (45 characters) */
+    UUID_STYLE_CANONICAL_UPPER = 4 /**< XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX (36 characters) */
+} uuid_style_t;
+
 /**
  * @brief Error codes returned by UUID functions
  */
@@ -24,6 +35,7 @@ typedef enum {
     UUID_ENTROPY_FAILURE = 1,  /**< Failed to generate random data from entropy source */
     UUID_INVALID_PARAMETER = 2, /**< Invalid parameter (null pointer, invalid size, etc.) */
     UUID_BUFFER_TOO_SMALL = 3,  /**< Buffer too small for output */
+    UUID_INVALID_FORMAT = 4,    /**< Input is not a valid UUID string */
     UUID_UNKNOWN_ERROR = 99     /**< Unknown error occurred */
 } uuid_error_t;
 
@@ -49,6 +61,230 @@ typedef enum {
  */
 int32_t uuid_generate_v4(uint8_t* uuid_bytes);
 
+/**
+ * @brief Generate a new UUID v1
+ * 
+ * Generates an RFC 4122 time-based UUID v1 from a 60-bit timestamp, a
+ * clock sequence, and a node identifier. The node is a random 48-bit value
+ * with the multicast bit set, chosen once per process.
+ * 
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ */
+int32_t uuid_generate_v1(uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate a new UUID v1 with a specific node identifier
+ * 
+ * Same as uuid_generate_v1, but stores the given node (typically a MAC
+ * address) unchanged in the last six bytes.
+ * 
+ * @param node Pointer to the 6-byte node identifier
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ */
+int32_t uuid_generate_v1_with_node(const uint8_t* node, uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate a new DCE Security UUID v2
+ * 
+ * Generates a UUID v1 layout in which the time_low field holds a local
+ * identifier (such as a POSIX UID or GID) and the clock_seq_low byte holds
+ * the local domain, with version 2.
+ * 
+ * @param domain Local domain (0 person, 1 group, 2 organization)
+ * @param id Local identifier within the domain
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ */
+int32_t uuid_generate_v2(uint8_t domain, uint32_t id, uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate a new UUID v6
+ * 
+ * Generates an RFC 9562 reordered time-based UUID v6. It carries the same
+ * timestamp, clock sequence, and node as a v1 UUID, but stores the
+ * timestamp most significant bits first so UUIDs sort in creation order.
+ * 
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ */
+int32_t uuid_generate_v6(uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate multiple UUID v4s in one call
+ * 
+ * Fills a contiguous buffer with count UUID v4s, laid out back to back as
+ * 16-byte records. All randomness is collected in a single read, which is
+ * much cheaper than calling uuid_generate_v4 in a loop.
+ * 
+ * @param uuid_bytes Pointer to a buffer of count * 16 bytes
+ * @param count Number of UUIDs to generate
+ * @return UUID_SUCCESS on success, error code on failure
+ * 
+ * @example
+ * ```c
+ * uint8_t uuids[100][16];
+ * int result = uuid_generate_v4_batch(&uuids[0][0], 100);
+ * ```
+ */
+int32_t uuid_generate_v4_batch(uint8_t* uuid_bytes, size_t count);
+
+/**
+ * @brief Opaque handle to a context for repeated UUID v4 generation
+ */
+typedef struct UuidContext UuidContext;
+
+/**
+ * @brief Allocate a context for repeated UUID v4 generation
+ * 
+ * The context buffers random data between calls to uuid_generate_v4_ctx, so
+ * the entropy source is read far less often than with uuid_generate_v4. A
+ * context must not be used from more than one thread at a time.
+ * 
+ * @return Context handle to release with uuid_context_free
+ */
+UuidContext* uuid_context_new(void);
+
+/**
+ * @brief Release a context returned by uuid_context_new
+ * 
+ * @param ctx Context to release (NULL is ignored)
+ */
+void uuid_context_free(UuidContext* ctx);
+
+/**
+ * @brief Generate a new UUID v4 from a context
+ * 
+ * @param ctx Context returned by uuid_context_new
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ * 
+ * @example
+ * ```c
+ * UuidContext* ctx = uuid_context_new();
+ * uint8_t uuid[16];
+ * for (int i = 0; i < 1000; i++) {
+ *     uuid_generate_v4_ctx(ctx, uuid);
+ * }
+ * uuid_context_free(ctx);
+ * ```
+ */
+int32_t uuid_generate_v4_ctx(UuidContext* ctx, uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate a new UUID v7
+ * 
+ * Generates a new RFC 9562 compliant UUID v7 carrying a 48-bit Unix
+ * millisecond timestamp, a 12-bit per-millisecond counter, and
+ * cryptographically secure randomness. UUIDs generated by the same process
+ * strictly increase, even when many are produced within one millisecond.
+ * 
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ * 
+ * @note The caller must ensure that uuid_bytes points to a valid 16-byte buffer.
+ */
+int32_t uuid_generate_v7(uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate a UUID v7 for a given timestamp
+ * 
+ * Same layout as uuid_generate_v7, but uses the supplied timestamp instead
+ * of the current time and fills every bit after it with randomness, without
+ * the per-millisecond counter. Intended for tests that need UUIDs at known
+ * times.
+ * 
+ * @param unix_ms Milliseconds since the Unix epoch (must fit in 48 bits)
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ */
+int32_t uuid_generate_v7_at(uint64_t unix_ms, uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate a name-based UUID v5
+ * 
+ * Derives a deterministic RFC 4122 UUID v5 from the SHA-1 hash of the
+ * namespace UUID followed by the name. The same inputs always yield the
+ * same UUID.
+ * 
+ * @param namespace_bytes Pointer to the 16-byte namespace UUID
+ * @param name Pointer to the name bytes (may be NULL when name_len is 0)
+ * @param name_len Length of the name in bytes
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ * 
+ * @example
+ * ```c
+ * const char* name = "python.org";
+ * uint8_t uuid[16];
+ * uuid_generate_v5(dns_namespace, (const uint8_t*)name, strlen(name), uuid);
+ * ```
+ */
+int32_t uuid_generate_v5(const uint8_t* namespace_bytes, const uint8_t* name, size_t name_len, uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate multiple name-based UUID v5s in one call
+ * 
+ * Hashes each name under the same namespace, writing the results back to
+ * back as 16-byte records. Each record equals what uuid_generate_v5 returns
+ * for that name.
+ * 
+ * @param namespace_bytes Pointer to the 16-byte namespace UUID
+ * @param names Pointer to the names concatenated (may be NULL when all names are empty)
+ * @param name_lens Pointer to count name lengths, in the order the names appear
+ * @param count Number of names
+ * @param uuid_bytes Pointer to a buffer of count * 16 bytes
+ * @return UUID_SUCCESS on success, error code on failure
+ * 
+ * @example
+ * ```c
+ * const char* names = "python.orgexample.com";
+ * size_t lens[2] = {10, 11};
+ * uint8_t uuids[2][16];
+ * uuid_generate_v5_batch(dns_namespace, (const uint8_t*)names, lens, 2, &uuids[0][0]);
+ * ```
+ */
+int32_t uuid_generate_v5_batch(const uint8_t* namespace_bytes, const uint8_t* names, const size_t* name_lens, size_t count, uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate a name-based UUID v3
+ * 
+ * Same as uuid_generate_v5 but hashes the namespace and name with MD5 and
+ * sets version 3. Provided for compatibility with existing v3 identifiers.
+ * 
+ * @param namespace_bytes Pointer to the 16-byte namespace UUID
+ * @param name Pointer to the name bytes (may be NULL when name_len is 0)
+ * @param name_len Length of the name in bytes
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ */
+int32_t uuid_generate_v3(const uint8_t* namespace_bytes, const uint8_t* name, size_t name_len, uint8_t* uuid_bytes);
+
+/**
+ * @brief Generate a custom UUID v8
+ * 
+ * Copies the 16 supplied bytes and overwrites only the version nibble (8)
+ * and the RFC 4122 variant bits, so application-specific data can be
+ * embedded in an otherwise valid UUID.
+ * 
+ * @param data Pointer to the 16 bytes to embed
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ */
+int32_t uuid_generate_v8(const uint8_t* data, uint8_t* uuid_bytes);
+
+/**
+ * @brief Get 16 raw random bytes
+ * 
+ * Fills the buffer with cryptographically secure random data without
+ * setting version or variant bits, for callers that stamp their own.
+ * 
+ * @param out Pointer to a 16-byte buffer where the bytes will be written
+ * @return UUID_SUCCESS on success, error code on failure
+ */
+int32_t uuid_random_bytes(uint8_t* out);
+
 /**
  * @brief Convert UUID bytes to string representation
  * 
@@ -78,6 +314,79 @@ int32_t uuid_generate_v4(uint8_t* uuid_bytes);
  */
 int32_t uuid_to_string(const uint8_t* uuid_bytes, char* uuid_string, size_t buffer_size);
 
+/**
+ * @brief Convert UUID bytes to a string in the given style
+ * 
+ * Works like uuid_to_string but supports the alternative representations
+ * listed in uuid_style_t. uuid_to_string is equivalent to calling this with
+ * UUID_STYLE_CANONICAL.
+ * 
+ * @param uuid_bytes Pointer to a 16-byte UUID
+ * @param style One of the uuid_style_t values
+ * @param uuid_string Pointer to a buffer where the string will be written
+ * @param buffer_size Size of the string buffer (style length + 1 for the null terminator)
+ * @return UUID_SUCCESS on success, UUID_INVALID_PARAMETER for an unknown style,
+ *         UUID_BUFFER_TOO_SMALL if the buffer cannot hold the result
+ * 
+ * @example
+ * ```c
+ * char guid_str[39];
+ * uuid_to_string_styled(uuid, UUID_STYLE_BRACED, guid_str, sizeof(guid_str));
+ * ```
+ */
+int32_t uuid_to_string_styled(const uint8_t* uuid_bytes, uint32_t style, char* uuid_string, size_t buffer_size);
+
+/**
+ * @brief Convert UUID bytes to a newly allocated string in the given style
+ * 
+ * Like uuid_to_string_styled, but the library allocates the result. The
+ * returned string must be released with uuid_free_string.
+ * 
+ * @param uuid_bytes Pointer to a 16-byte UUID
+ * @param style One of the uuid_style_t values
+ * @return Pointer to the formatted string, or NULL on invalid parameters
+ * 
+ * @example
+ * ```c
+ * char* urn = uuid_to_string_alloc(uuid, UUID_STYLE_URN);
+ * if (urn != NULL) {
+ *     printf("%s\n", urn);
+ *     uuid_free_string(urn);
+ * }
+ * ```
+ */
+char* uuid_to_string_alloc(const uint8_t* uuid_bytes, uint32_t style);
+
+/**
+ * @brief Free a string returned by the library
+ * 
+ * @param uuid_string Pointer returned by uuid_to_string_alloc (NULL is ignored)
+ * 
+ * @note Strings must not be freed with free(); they were allocated by Rust.
+ */
+void uuid_free_string(char* uuid_string);
+
+/**
+ * @brief Parse a UUID from its string representation
+ * 
+ * Parses the canonical xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form into
+ * 16 UUID bytes. The hyphenless, braced ({...}) and URN (urn:uuid:...)
+ * forms are accepted as well. Hex digits may be upper or lower case.
+ * 
+ * @param uuid_string Pointer to the string to parse (need not be null-terminated)
+ * @param string_len Length of the string in bytes
+ * @param uuid_bytes Pointer to a 16-byte buffer where the UUID will be written
+ * @return UUID_SUCCESS on success, UUID_INVALID_FORMAT if the string is malformed
+ * 
+ * @example
+ * ```c
+ * const char* str = "550e8400-e29b-41d4-a716-446655440000";
+ * uint8_t uuid[16];
+ * int result = uuid_from_string(str, strlen(str), uuid);
+ * ```
+ */
+int32_t uuid_from_string(const char* uuid_string, size_t string_len, uint8_t* uuid_bytes);
+
 /**
  * @brief Get UUID version and variant information
  * 
@@ -104,6 +413,21 @@ int32_t uuid_to_string(const uint8_t* uuid_bytes, char* uuid_string, size_t buff
  */
 int32_t uuid_get_info(const uint8_t* uuid_bytes, uint8_t* version, uint8_t* variant);
 
+/**
+ * @brief Get the timestamp embedded in a time-based UUID
+ * 
+ * For versions 1 and 6 the timestamp is the 60-bit count of 100-nanosecond
+ * intervals since 1582-10-15 00:00:00 UTC. For version 7 it is the 48-bit
+ * count of milliseconds since the Unix epoch. Other versions carry no
+ * timestamp.
+ * 
+ * @param uuid_bytes Pointer to a 16-byte UUID
+ * @param timestamp Pointer to where the timestamp will be written (0 if none)
+ * @param has_timestamp Pointer to where the flag will be written (1 if present, 0 if not)
+ * @return UUID_SUCCESS on success, error code on failure
+ */
+int32_t uuid_get_timestamp(const uint8_t* uuid_bytes, uint64_t* timestamp, uint8_t* has_timestamp);
+
 /**
  * @brief Compare two UUIDs for equality
  * 
@@ -130,6 +454,30 @@ int32_t uuid_get_info(const uint8_t* uuid_bytes, uint8_t* version, uint8_t* vari
  */
 int32_t uuid_compare(const uint8_t* uuid1_bytes, const uint8_t* uuid2_bytes, uint8_t* are_equal);
 
+/**
+ * @brief Get the detail message of the last failure on this thread
+ * 
+ * Error codes only describe the category of a failure. After a function
+ * returns UUID_ENTROPY_FAILURE or UUID_INVALID_FORMAT, this returns the
+ * underlying cause, such as the OS error raised while reading /dev/urandom.
+ * Must be called on the same thread as the failing call.
+ * 
+ * @param buffer Pointer to a buffer where the null-terminated message will be written
+ * @param buffer_size Size of the buffer in bytes
+ * @return UUID_SUCCESS on success (empty message if nothing was recorded),
+ *         UUID_BUFFER_TOO_SMALL if the message does not fit
+ * 
+ * @example
+ * ```c
+ * if (uuid_generate_v4(uuid) == UUID_ENTROPY_FAILURE) {
+ *     char detail[256];
+ *     uuid_last_error(detail, sizeof(detail));
+ *     fprintf(stderr, "entropy failure: %s\n", detail);
+ * }
+ * ```
+ */
+int32_t uuid_last_error(char* buffer, size_t buffer_size);
+
 /**
  * @brief Get error message for error code
  * 
@@ -156,6 +504,8 @@ static inline const char* uuid_error_string(int32_t error_code) {
             return "Invalid parameter";
         case UUID_BUFFER_TOO_SMALL:
             return "Buffer too small";
+        case UUID_INVALID_FORMAT:
+            return "Invalid UUID string format";
         case UUID_UNKNOWN_ERROR:
             return "Unknown error";
         default:
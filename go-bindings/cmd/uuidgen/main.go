@@ -0,0 +1,158 @@
+// Command uuidgen prints UUIDs generated by the Rust library, one per line.
+//
+// Usage:
+//
+//	uuidgen [-n count] [-v 1|4|5|7] [-f canonical|simple|urn|braced] [-namespace ns -name name]
+//
+// For version 5, -namespace accepts dns, url, oid, x500, or any UUID string.
+package main
+
+/*
+#cgo LDFLAGS: -L../../../target/release -luuid_generator
+#include <stdint.h>
+#include <stdlib.h>
+
+int32_t uuid_generate_v1(uint8_t* uuid_bytes);
+int32_t uuid_generate_v4(uint8_t* uuid_bytes);
+int32_t uuid_generate_v7(uint8_t* uuid_bytes);
+int32_t uuid_generate_v5(const uint8_t* namespace_bytes, const uint8_t* name, size_t name_len, uint8_t* uuid_bytes);
+int32_t uuid_to_string_styled(const uint8_t* uuid_bytes, uint32_t style, char* uuid_string, size_t buffer_size);
+int32_t uuid_from_string(const char* uuid_string, size_t string_len, uint8_t* uuid_bytes);
+*/
+import "C"
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unsafe"
+)
+
+// Format styles understood by uuid_to_string_styled.
+var styles = map[string]C.uint32_t{
+	"canonical": 0,
+	"braced":    1,
+	"simple":    2,
+	"urn":       3,
+}
+
+// Well-known namespaces from RFC 4122 appendix C.
+var namespaces = map[string]string{
+	"dns":  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	"url":  "6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+	"oid":  "6ba7b812-9dad-11d1-80b4-00c04fd430c8",
+	"x500": "6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "uuidgen:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run parses args, then writes the requested UUIDs to stdout. Usage and flag
+// errors go to stderr.
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("uuidgen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	count := flags.Int("n", 1, "number of UUIDs to generate")
+	version := flags.Int("v", 4, "UUID version: 1, 4, 5, or 7")
+	format := flags.String("f", "canonical", "output format: canonical, simple, urn, or braced")
+	namespace := flags.String("namespace", "", "v5 namespace: dns, url, oid, x500, or a UUID")
+	name := flags.String("name", "", "v5 name")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	if *count < 0 {
+		return fmt.Errorf("-n must not be negative, got %d", *count)
+	}
+
+	style, ok := styles[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	var generate func() ([16]C.uint8_t, error)
+	switch *version {
+	case 1:
+		generate = generator(func(out *C.uint8_t) C.int32_t { return C.uuid_generate_v1(out) })
+	case 4:
+		generate = generator(func(out *C.uint8_t) C.int32_t { return C.uuid_generate_v4(out) })
+	case 7:
+		generate = generator(func(out *C.uint8_t) C.int32_t { return C.uuid_generate_v7(out) })
+	case 5:
+		if *namespace == "" {
+			return fmt.Errorf("-v 5 requires -namespace")
+		}
+		ns, err := parseNamespace(*namespace)
+		if err != nil {
+			return err
+		}
+		generate = generator(func(out *C.uint8_t) C.int32_t {
+			var cName *C.uint8_t
+			if len(*name) > 0 {
+				cName = (*C.uint8_t)(unsafe.Pointer(unsafe.StringData(*name)))
+			}
+			return C.uuid_generate_v5(&ns[0], cName, C.size_t(len(*name)), out)
+		})
+	default:
+		return fmt.Errorf("unsupported version %d", *version)
+	}
+
+	w := bufio.NewWriter(stdout)
+	for i := 0; i < *count; i++ {
+		uuid, err := generate()
+		if err != nil {
+			return err
+		}
+
+		var buffer [46]C.char
+		if result := C.uuid_to_string_styled(&uuid[0], style, &buffer[0], 46); result != 0 {
+			return fmt.Errorf("formatting failed with error code %d", int32(result))
+		}
+
+		fmt.Fprintln(w, C.GoString(&buffer[0]))
+	}
+
+	return w.Flush()
+}
+
+// generator adapts an FFI generation call into a function returning the
+// UUID bytes or an error carrying the FFI error code.
+func generator(call func(out *C.uint8_t) C.int32_t) func() ([16]C.uint8_t, error) {
+	return func() ([16]C.uint8_t, error) {
+		var uuid [16]C.uint8_t
+		if result := call(&uuid[0]); result != 0 {
+			return uuid, fmt.Errorf("generation failed with error code %d", int32(result))
+		}
+		return uuid, nil
+	}
+}
+
+// parseNamespace resolves a well-known namespace name or parses a UUID string.
+func parseNamespace(s string) ([16]C.uint8_t, error) {
+	var ns [16]C.uint8_t
+
+	if known, ok := namespaces[strings.ToLower(s)]; ok {
+		s = known
+	}
+
+	cStr := C.CString(s)
+	defer C.free(unsafe.Pointer(cStr))
+
+	if result := C.uuid_from_string(cStr, C.size_t(len(s)), &ns[0]); result != 0 {
+		return ns, fmt.Errorf("invalid namespace %q", s)
+	}
+
+	return ns, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// runCLI invokes run with args and returns the output lines.
+func runCLI(t *testing.T, args ...string) ([]string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	err := run(args, &stdout, &stderr)
+	return strings.Fields(stdout.String()), err
+}
+
+func TestRunCount(t *testing.T) {
+	for _, n := range []int{0, 1, 5} {
+		lines, err := runCLI(t, "-n", strconv.Itoa(n))
+		if err != nil {
+			t.Fatalf("run(-n %d) error = %v", n, err)
+		}
+		if len(lines) != n {
+			t.Errorf("run(-n %d) printed %d lines", n, len(lines))
+		}
+	}
+}
+
+func TestRunVersions(t *testing.T) {
+	tests := []struct {
+		args    []string
+		version string
+	}{
+		{[]string{"-v", "1"}, "1"},
+		{[]string{"-v", "4"}, "4"},
+		{[]string{"-v", "7"}, "7"},
+		{[]string{"-v", "5", "-namespace", "dns", "-name", "python.org"}, "5"},
+		{[]string{"-v", "5", "-namespace", "6ba7b811-9dad-11d1-80b4-00c04fd430c8", "-name", "a"}, "5"},
+	}
+	for _, tt := range tests {
+		lines, err := runCLI(t, append(tt.args, "-n", "3")...)
+		if err != nil {
+			t.Fatalf("run(%v) error = %v", tt.args, err)
+		}
+		if len(lines) != 3 {
+			t.Fatalf("run(%v) printed %d lines, want 3", tt.args, len(lines))
+		}
+		for _, line := range lines {
+			if len(line) != 36 || line[14:15] != tt.version {
+				t.Errorf("run(%v) printed %q, want a version %s UUID", tt.args, line, tt.version)
+			}
+		}
+	}
+}
+
+func TestRunV5IsDeterministic(t *testing.T) {
+	lines, err := runCLI(t, "-v", "5", "-namespace", "dns", "-name", "python.org")
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if len(lines) != 1 || lines[0] != "886313e1-3b8a-5372-9b90-0c9aee199e5d" {
+		t.Errorf("run() printed %v, want the RFC 4122 python.org UUID", lines)
+	}
+}
+
+func TestRunFormats(t *testing.T) {
+	const canonical = `[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}`
+
+	tests := []struct {
+		format  string
+		pattern string
+	}{
+		{"canonical", `^` + canonical + `$`},
+		{"simple", `^[0-9a-f]{12}4[0-9a-f]{3}[89ab][0-9a-f]{15}$`},
+		{"urn", `^urn:uuid:` + canonical + `$`},
+		{"braced", `^\{` + canonical + `\}$`},
+	}
+	for _, tt := range tests {
+		lines, err := runCLI(t, "-f", tt.format, "-n", "2")
+		if err != nil {
+			t.Fatalf("run(-f %s) error = %v", tt.format, err)
+		}
+		re := regexp.MustCompile(tt.pattern)
+		for _, line := range lines {
+			if !re.MatchString(line) {
+				t.Errorf("run(-f %s) printed %q, want a match for %s", tt.format, line, tt.pattern)
+			}
+		}
+	}
+}
+
+func TestRunRejectsBadFlags(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-v", "5", "-name", "python.org"}, "-v 5 requires -namespace"},
+		{[]string{"-n", "-1"}, "-n must not be negative"},
+		{[]string{"-v", "3"}, "unsupported version 3"},
+		{[]string{"-f", "hex"}, `unknown format "hex"`},
+		{[]string{"-v", "5", "-namespace", "bogus"}, `invalid namespace "bogus"`},
+	}
+	for _, tt := range tests {
+		lines, err := runCLI(t, tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("run(%v) error = %v, want it to contain %q", tt.args, err, tt.want)
+		}
+		if len(lines) != 0 {
+			t.Errorf("run(%v) printed %v, want no output", tt.args, lines)
+		}
+	}
+}
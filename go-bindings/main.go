@@ -6,134 +6,2290 @@ package main
 #include <stdlib.h>
 
 // FFI function declarations
+int32_t uuid_generate_v1(uint8_t* uuid_bytes);
+int32_t uuid_generate_v1_with_node(const uint8_t* node, uint8_t* uuid_bytes);
+int32_t uuid_generate_v2(uint8_t domain, uint32_t id, uint8_t* uuid_bytes);
+int32_t uuid_generate_v6(uint8_t* uuid_bytes);
 int32_t uuid_generate_v4(uint8_t* uuid_bytes);
+int32_t uuid_generate_v4_batch(uint8_t* uuid_bytes, size_t count);
+typedef struct UuidContext UuidContext;
+UuidContext* uuid_context_new(void);
+void uuid_context_free(UuidContext* ctx);
+int32_t uuid_generate_v4_ctx(UuidContext* ctx, uint8_t* uuid_bytes);
+int32_t uuid_generate_v7(uint8_t* uuid_bytes);
+int32_t uuid_generate_v7_at(uint64_t unix_ms, uint8_t* uuid_bytes);
+int32_t uuid_generate_v5(const uint8_t* namespace_bytes, const uint8_t* name, size_t name_len, uint8_t* uuid_bytes);
+int32_t uuid_generate_v5_batch(const uint8_t* namespace_bytes, const uint8_t* names, const size_t* name_lens, size_t count, uint8_t* uuid_bytes);
+int32_t uuid_generate_v3(const uint8_t* namespace_bytes, const uint8_t* name, size_t name_len, uint8_t* uuid_bytes);
+int32_t uuid_generate_v8(const uint8_t* data, uint8_t* uuid_bytes);
+int32_t uuid_random_bytes(uint8_t* out);
 int32_t uuid_to_string(const uint8_t* uuid_bytes, char* uuid_string, size_t buffer_size);
+int32_t uuid_to_string_styled(const uint8_t* uuid_bytes, uint32_t style, char* uuid_string, size_t buffer_size);
+char* uuid_to_string_alloc(const uint8_t* uuid_bytes, uint32_t style);
+void uuid_free_string(char* uuid_string);
+int32_t uuid_from_string(const char* uuid_string, size_t string_len, uint8_t* uuid_bytes);
 int32_t uuid_get_info(const uint8_t* uuid_bytes, uint8_t* version, uint8_t* variant);
+int32_t uuid_get_timestamp(const uint8_t* uuid_bytes, uint64_t* timestamp, uint8_t* has_timestamp);
 int32_t uuid_compare(const uint8_t* uuid1_bytes, const uint8_t* uuid2_bytes, uint8_t* are_equal);
+int32_t uuid_last_error(char* buffer, size_t buffer_size);
 */
 import "C"
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
 )
 
 type UUIDError struct {
 	Code    int32
 	Message string
+	Detail  string
 }
 
 func (e UUIDError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("UUID error %d: %s: %s", e.Code, e.Message, e.Detail)
+	}
 	return fmt.Sprintf("UUID error %d: %s", e.Code, e.Message)
 }
 
-type UUID struct {
-	bytes [16]byte
+// Sentinel errors matched by errors.Is against a UUIDError of the
+// corresponding code.
+var (
+	ErrEntropyFailure   = errors.New("entropy failure")
+	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrBufferTooSmall   = errors.New("buffer too small")
+	ErrInvalidFormat    = errors.New("invalid UUID format")
+	ErrUnknown          = errors.New("unknown error")
+)
+
+func (e UUIDError) Unwrap() error {
+	switch e.Code {
+	case 0:
+		return nil
+	case 1:
+		return ErrEntropyFailure
+	case 2:
+		return ErrInvalidParameter
+	case 3:
+		return ErrBufferTooSmall
+	case 4:
+		return ErrInvalidFormat
+	default:
+		return ErrUnknown
+	}
+}
+
+// errorWithDetail builds the error for a failed FFI call, including the
+// underlying cause of entropy and format failures. The Rust side records that
+// cause per OS thread, so callers must hold runtime.LockOSThread across both
+// calls.
+func errorWithDetail(result C.int32_t) UUIDError {
+	err := UUIDError{
+		Code:    int32(result),
+		Message: getErrorMessage(int32(result)),
+	}
+
+	if result == 1 || result == 4 {
+		var buffer [512]C.char
+		if C.uuid_last_error(&buffer[0], 512) == 0 {
+			err.Detail = C.GoString(&buffer[0])
+		}
+	}
+
+	return err
+}
+
+type UUID struct {
+	bytes [16]byte
+}
+
+var (
+	NamespaceDNS  = FromBytes([16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8})
+	NamespaceURL  = FromBytes([16]byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8})
+	NamespaceOID  = FromBytes([16]byte{0x6b, 0xa7, 0xb8, 0x12, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8})
+	NamespaceX500 = FromBytes([16]byte{0x6b, 0xa7, 0xb8, 0x14, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8})
+)
+
+type formatStyle uint32
+
+const (
+	styleCanonical formatStyle = iota
+	styleBraced
+	styleSimple
+	styleURN
+	styleCanonicalUpper
+)
+
+// defaultNode is the node set by SetDefaultNode, if any.
+var defaultNode struct {
+	mu   sync.Mutex
+	node [6]byte
+	set  bool
+}
+
+// SetDefaultNode makes NewV1 embed node instead of the random per-process
+// node chosen by the Rust library.
+func SetDefaultNode(node [6]byte) {
+	defaultNode.mu.Lock()
+	defer defaultNode.mu.Unlock()
+
+	defaultNode.node = node
+	defaultNode.set = true
+}
+
+// resetDefaultNode undoes SetDefaultNode so NewV1 goes back to the node chosen
+// by the Rust library.
+func resetDefaultNode() {
+	defaultNode.mu.Lock()
+	defer defaultNode.mu.Unlock()
+
+	defaultNode.node = [6]byte{}
+	defaultNode.set = false
+}
+
+func NewV1() (*UUID, error) {
+	defaultNode.mu.Lock()
+	node, set := defaultNode.node, defaultNode.set
+	defaultNode.mu.Unlock()
+	if set {
+		return NewV1WithNode(node)
+	}
+
+	var uuid UUID
+	var cBytes [16]C.uint8_t
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v1(&cBytes[0])
+	if result != 0 {
+		return nil, errorWithDetail(result)
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+func NewV1WithNode(node [6]byte) (*UUID, error) {
+	var uuid UUID
+	var cNode [6]C.uint8_t
+	var cBytes [16]C.uint8_t
+
+	for i := 0; i < 6; i++ {
+		cNode[i] = C.uint8_t(node[i])
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v1_with_node(&cNode[0], &cBytes[0])
+	if result != 0 {
+		return nil, errorWithDetail(result)
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+func NewV4() (*UUID, error) {
+	var uuid UUID
+	var cBytes [16]C.uint8_t
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v4(&cBytes[0])
+	if result != 0 {
+		return nil, errorWithDetail(result)
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+// V4FromRandom reads 16 bytes from r and stamps them as a v4 UUID. Passing
+// crypto/rand.Reader behaves like NewV4; a fixed reader gives deterministic
+// UUIDs for tests. A short read is reported as an entropy failure.
+func V4FromRandom(r io.Reader) (*UUID, error) {
+	var uuid UUID
+	if _, err := io.ReadFull(r, uuid.bytes[:]); err != nil {
+		return nil, UUIDError{
+			Code:    1,
+			Message: getErrorMessage(1),
+			Detail:  err.Error(),
+		}
+	}
+
+	stampVersion(&uuid.bytes, 4)
+	return &uuid, nil
+}
+
+// GenerateV4Into writes a new v4 UUID into u, letting the Rust library fill
+// u's bytes directly so no UUID is allocated.
+func GenerateV4Into(u *UUID) error {
+	if u == nil {
+		return UUIDError{
+			Code:    2,
+			Message: getErrorMessage(2),
+		}
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v4((*C.uint8_t)(unsafe.Pointer(&u.bytes[0])))
+	if result != 0 {
+		return errorWithDetail(result)
+	}
+
+	return nil
+}
+
+// NewV4WithPrefix returns a v4 UUID whose leading bytes are copied from
+// prefix, which makes test data easy to recognize. The rest is random. The
+// version and variant are stamped afterwards, so the high nibble of byte 6 and
+// the top two bits of byte 8 never match a prefix that covers them. Prefixes
+// longer than 16 bytes are rejected.
+func NewV4WithPrefix(prefix []byte) (*UUID, error) {
+	if len(prefix) > 16 {
+		return nil, UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("prefix of %d bytes does not fit in a UUID", len(prefix)),
+		}
+	}
+
+	uuid, err := NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	copy(uuid.bytes[:], prefix)
+	stampVersion(&uuid.bytes, 4)
+	return uuid, nil
+}
+
+// NewV4Retry calls NewV4 up to attempts times, sleeping delay between tries,
+// for as long as it fails with ErrEntropyFailure. Other errors are returned
+// immediately. An attempts value below 1 counts as 1.
+func NewV4Retry(attempts int, delay time.Duration) (*UUID, error) {
+	return retryEntropy(attempts, delay, NewV4)
+}
+
+// retryEntropy implements NewV4Retry for any v4 generation function.
+func retryEntropy(attempts int, delay time.Duration, generate func() (*UUID, error)) (*UUID, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+
+		var uuid *UUID
+		uuid, err = generate()
+		if err == nil || !errors.Is(err, ErrEntropyFailure) {
+			return uuid, err
+		}
+	}
+
+	return nil, err
+}
+
+func NewV4Batch(n int) ([]*UUID, error) {
+	if n < 0 {
+		return nil, UUIDError{
+			Code:    2,
+			Message: getErrorMessage(2),
+		}
+	}
+
+	raw := make([]byte, n*16)
+	if err := fillV4Batch(raw); err != nil {
+		return nil, err
+	}
+
+	values := make([]UUID, n)
+	uuids := make([]*UUID, n)
+	for i := range values {
+		copy(values[i].bytes[:], raw[i*16:])
+		uuids[i] = &values[i]
+	}
+
+	return uuids, nil
+}
+
+// GenerateV4Packed returns n v4 UUIDs packed back to back in a single
+// 16*n-byte slice, without allocating a UUID per value.
+func GenerateV4Packed(n int) ([]byte, error) {
+	if n < 0 {
+		return nil, UUIDError{
+			Code:    2,
+			Message: getErrorMessage(2),
+		}
+	}
+
+	raw := make([]byte, n*16)
+	if err := fillV4Batch(raw); err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+// NewV4BatchContext is like NewV4Batch but generates in chunks of
+// batchChunkSize, checking ctx between chunks. If ctx is cancelled, the UUIDs
+// generated so far are returned together with ctx.Err().
+func NewV4BatchContext(ctx context.Context, n int) ([]*UUID, error) {
+	if n < 0 {
+		return nil, UUIDError{
+			Code:    2,
+			Message: getErrorMessage(2),
+		}
+	}
+
+	raw := make([]byte, batchChunkSize*16)
+	values := make([]UUID, n)
+	uuids := make([]*UUID, 0, n)
+
+	for len(uuids) < n {
+		if err := ctx.Err(); err != nil {
+			return uuids, err
+		}
+
+		count := n - len(uuids)
+		if count > batchChunkSize {
+			count = batchChunkSize
+		}
+
+		if err := fillV4Batch(raw[:count*16]); err != nil {
+			return uuids, err
+		}
+
+		for i := 0; i < count; i++ {
+			uuid := &values[len(uuids)]
+			copy(uuid.bytes[:], raw[i*16:])
+			uuids = append(uuids, uuid)
+		}
+	}
+
+	return uuids, nil
+}
+
+// batchChunkSize is the number of UUIDs requested per FFI call by the
+// chunked batch helpers.
+const batchChunkSize = 1024
+
+// fillV4Batch fills buf, whose length must be a multiple of 16, with
+// consecutive v4 UUIDs using a single FFI call.
+func fillV4Batch(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v4_batch((*C.uint8_t)(unsafe.Pointer(&buf[0])), C.size_t(len(buf)/16))
+	if result != 0 {
+		return errorWithDetail(result)
+	}
+
+	return nil
+}
+
+func StreamV4(w io.Writer, count int, sep string) (int, error) {
+	raw := make([]byte, batchChunkSize*16)
+	line := make([]byte, 0, 36+len(sep))
+
+	written := 0
+	for written < count {
+		n := count - written
+		if n > batchChunkSize {
+			n = batchChunkSize
+		}
+
+		if err := fillV4Batch(raw[:n*16]); err != nil {
+			return written, err
+		}
+
+		for i := 0; i < n; i++ {
+			line = line[:0]
+			if written > 0 {
+				line = append(line, sep...)
+			}
+
+			var b [16]byte
+			copy(b[:], raw[i*16:])
+			line = appendCanonical(line, &b)
+
+			if _, err := w.Write(line); err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+
+	return written, nil
+}
+
+// NewV2 returns a DCE Security UUID carrying a local domain, such as 0 for a
+// POSIX UID or 1 for a GID, and an identifier within it.
+func NewV2(domain uint8, id uint32) (*UUID, error) {
+	var uuid UUID
+	var cBytes [16]C.uint8_t
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v2(C.uint8_t(domain), C.uint32_t(id), &cBytes[0])
+	if result != 0 {
+		return nil, errorWithDetail(result)
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+func NewV6() (*UUID, error) {
+	var uuid UUID
+	var cBytes [16]C.uint8_t
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v6(&cBytes[0])
+	if result != 0 {
+		return nil, errorWithDetail(result)
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+func NewV7() (*UUID, error) {
+	var uuid UUID
+	var cBytes [16]C.uint8_t
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v7(&cBytes[0])
+	if result != 0 {
+		return nil, errorWithDetail(result)
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+// NewV7At returns a UUID v7 carrying t, truncated to the millisecond, with
+// random bits after the timestamp. Times before the Unix epoch or beyond the
+// 48-bit range are rejected with an InvalidParameter error.
+func NewV7At(t time.Time) (*UUID, error) {
+	var uuid UUID
+	var cBytes [16]C.uint8_t
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v7_at(C.uint64_t(t.UnixMilli()), &cBytes[0])
+	if result != 0 {
+		return nil, errorWithDetail(result)
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+// NewV5 derives a name-based UUID from the SHA-1 hash of namespace and name.
+// Any UUID is a valid namespace, but a nil namespace is rejected with
+// ErrInvalidParameter before calling into Rust. The same applies to NewV3.
+func NewV5(namespace *UUID, name []byte) (*UUID, error) {
+	return newNameBased(namespace, name, 5)
+}
+
+// NewV5Batch returns the v5 UUID of each name under namespace, in order,
+// hashing them all in a single FFI call. Each result equals NewV5 for the
+// same name.
+func NewV5Batch(namespace *UUID, names [][]byte) ([]*UUID, error) {
+	if namespace == nil {
+		return nil, UUIDError{
+			Code:    2,
+			Message: "namespace must not be nil",
+		}
+	}
+
+	if len(names) == 0 {
+		return []*UUID{}, nil
+	}
+
+	var cNamespace [16]C.uint8_t
+	for i := 0; i < 16; i++ {
+		cNamespace[i] = C.uint8_t(namespace.bytes[i])
+	}
+
+	// The names are packed into one buffer because cgo does not allow passing
+	// Go memory that itself holds Go pointers.
+	var joined []byte
+	lens := make([]C.size_t, len(names))
+	for i, name := range names {
+		joined = append(joined, name...)
+		lens[i] = C.size_t(len(name))
+	}
+
+	var cNames *C.uint8_t
+	if len(joined) > 0 {
+		cNames = (*C.uint8_t)(unsafe.Pointer(&joined[0]))
+	}
+	raw := make([]byte, len(names)*16)
+
+	result := C.uuid_generate_v5_batch(&cNamespace[0], cNames, &lens[0], C.size_t(len(names)), (*C.uint8_t)(unsafe.Pointer(&raw[0])))
+	if result != 0 {
+		return nil, UUIDError{
+			Code:    int32(result),
+			Message: getErrorMessage(int32(result)),
+		}
+	}
+
+	values := make([]UUID, len(names))
+	uuids := make([]*UUID, len(names))
+	for i := range values {
+		copy(values[i].bytes[:], raw[i*16:])
+		uuids[i] = &values[i]
+	}
+
+	return uuids, nil
+}
+
+// NewV5String is NewV5 for a string name, which is hashed as its UTF-8 bytes.
+func NewV5String(namespace *UUID, name string) (*UUID, error) {
+	return NewV5(namespace, []byte(name))
+}
+
+// Child returns the v5 UUID for name using u as the namespace, shorthand for
+// NewV5String(u, name) when building trees of deterministic IDs.
+func (u *UUID) Child(name string) (*UUID, error) {
+	return NewV5String(u, name)
+}
+
+// NewFromSHA256 returns a content-addressed v8 UUID made of the first 16 bytes
+// of the SHA-256 digest of data. The same data always yields the same UUID.
+func NewFromSHA256(data []byte) *UUID {
+	var uuid UUID
+
+	digest := sha256.Sum256(data)
+	copy(uuid.bytes[:], digest[:16])
+	stampVersion(&uuid.bytes, 8)
+
+	return &uuid
+}
+
+func NewV3(namespace *UUID, name []byte) (*UUID, error) {
+	return newNameBased(namespace, name, 3)
+}
+
+func newNameBased(namespace *UUID, name []byte, version int) (*UUID, error) {
+	if namespace == nil {
+		return nil, UUIDError{
+			Code:    2,
+			Message: "namespace must not be nil",
+		}
+	}
+
+	var uuid UUID
+	var cNamespace, cBytes [16]C.uint8_t
+	var cName *C.uint8_t
+
+	for i := 0; i < 16; i++ {
+		cNamespace[i] = C.uint8_t(namespace.bytes[i])
+	}
+	if len(name) > 0 {
+		cName = (*C.uint8_t)(unsafe.Pointer(&name[0]))
+	}
+
+	var result C.int32_t
+	if version == 3 {
+		result = C.uuid_generate_v3(&cNamespace[0], cName, C.size_t(len(name)), &cBytes[0])
+	} else {
+		result = C.uuid_generate_v5(&cNamespace[0], cName, C.size_t(len(name)), &cBytes[0])
+	}
+	if result != 0 {
+		return nil, UUIDError{
+			Code:    int32(result),
+			Message: getErrorMessage(int32(result)),
+		}
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+func NewV8(data [16]byte) (*UUID, error) {
+	var uuid UUID
+	var cData, cBytes [16]C.uint8_t
+
+	for i := 0; i < 16; i++ {
+		cData[i] = C.uint8_t(data[i])
+	}
+
+	result := C.uuid_generate_v8(&cData[0], &cBytes[0])
+	if result != 0 {
+		return nil, UUIDError{
+			Code:    int32(result),
+			Message: getErrorMessage(int32(result)),
+		}
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+// RawRandom returns 16 bytes straight from the Rust CSPRNG. Unlike NewV4, no
+// version or variant bits are set.
+func RawRandom() ([16]byte, error) {
+	var raw [16]byte
+	var cBytes [16]C.uint8_t
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_random_bytes(&cBytes[0])
+	if result != 0 {
+		return raw, errorWithDetail(result)
+	}
+
+	for i := 0; i < 16; i++ {
+		raw[i] = byte(cBytes[i])
+	}
+
+	return raw, nil
+}
+
+type EntropySource interface {
+	Fill([]byte) error
+}
+
+// Generator produces UUIDs from an injectable EntropySource. A nil source
+// uses the Rust library's CSPRNG, exactly like the package-level NewV4.
+type Generator struct {
+	mu     sync.Mutex
+	source EntropySource
+}
+
+func NewGenerator(source EntropySource) *Generator {
+	return &Generator{source: source}
+}
+
+// NewSeededGenerator returns a Generator whose output is fully determined by
+// seed. It is intended for tests and must not be used where unpredictability
+// matters.
+func NewSeededGenerator(seed uint64) *Generator {
+	return NewGenerator(&seededSource{state: seed})
+}
+
+// DeterministicSequence returns n v4-shaped UUIDs derived from seed with the
+// same PRNG as NewSeededGenerator. It is meant for reproducible test fixtures
+// and must not be used where unpredictability matters.
+func DeterministicSequence(seed uint64, n int) []*UUID {
+	if n < 0 {
+		n = 0
+	}
+
+	source := seededSource{state: seed}
+	values := make([]UUID, n)
+	uuids := make([]*UUID, n)
+	for i := range values {
+		source.Fill(values[i].bytes[:])
+		stampVersion(&values[i].bytes, 4)
+		uuids[i] = &values[i]
+	}
+
+	return uuids
+}
+
+// Reseed restores a Generator created by NewSeededGenerator to the state it
+// had when created with seed, so the same sequence can be generated again.
+// It has no effect on Generators using any other source.
+func (g *Generator) Reseed(seed uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if source, ok := g.source.(*seededSource); ok {
+		source.state = seed
+	}
+}
+
+func (g *Generator) NewV4() (*UUID, error) {
+	if g.source == nil {
+		return NewV4()
+	}
+
+	var uuid UUID
+
+	g.mu.Lock()
+	err := g.source.Fill(uuid.bytes[:])
+	g.mu.Unlock()
+	if err != nil {
+		return nil, UUIDError{
+			Code:    1,
+			Message: getErrorMessage(1),
+			Detail:  err.Error(),
+		}
+	}
+
+	stampVersion(&uuid.bytes, 4)
+	return &uuid, nil
+}
+
+// BufferedGenerator hands out v4 UUIDs from a buffer that is refilled with a
+// single batch FFI call whenever it runs empty, amortizing the cgo cost over
+// many calls. It is safe for concurrent use.
+type BufferedGenerator struct {
+	mu   sync.Mutex
+	buf  []byte
+	next int
+}
+
+// NewBufferedGenerator returns a BufferedGenerator that prefetches bufSize
+// UUIDs at a time. A bufSize below 1 selects batchChunkSize.
+func NewBufferedGenerator(bufSize int) *BufferedGenerator {
+	if bufSize < 1 {
+		bufSize = batchChunkSize
+	}
+
+	buf := make([]byte, bufSize*16)
+	return &BufferedGenerator{buf: buf, next: len(buf)}
+}
+
+func (g *BufferedGenerator) Next() (*UUID, error) {
+	var uuid UUID
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.next == len(g.buf) {
+		if err := fillV4Batch(g.buf); err != nil {
+			return nil, err
+		}
+		g.next = 0
+	}
+
+	copy(uuid.bytes[:], g.buf[g.next:])
+	g.next += 16
+
+	return &uuid, nil
+}
+
+// uniqueMaxAttempts bounds how many times UniqueGenerator.Next regenerates
+// after drawing a UUID it has already issued.
+const uniqueMaxAttempts = 16
+
+// UniqueGenerator hands out v4 UUIDs that are guaranteed not to repeat within
+// its lifetime, regenerating on a collision with any UUID it has already
+// issued or reserved. It remembers every UUID, so memory grows with use. It
+// is safe for concurrent use.
+type UniqueGenerator struct {
+	mu   sync.Mutex
+	gen  *Generator
+	seen map[[16]byte]struct{}
+}
+
+// NewUniqueGenerator returns a UniqueGenerator drawing from source. A nil
+// source uses the Rust library's CSPRNG.
+func NewUniqueGenerator(source EntropySource) *UniqueGenerator {
+	return &UniqueGenerator{
+		gen:  NewGenerator(source),
+		seen: make(map[[16]byte]struct{}),
+	}
+}
+
+// Reserve marks uuid as already issued, so Next never returns it.
+func (g *UniqueGenerator) Reserve(uuid *UUID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.seen[uuid.bytes] = struct{}{}
+}
+
+// Next returns a v4 UUID that g has not issued or reserved before. If the
+// source keeps producing known UUIDs for uniqueMaxAttempts draws, as a broken
+// or constant source would, Next fails with ErrEntropyFailure.
+func (g *UniqueGenerator) Next() (*UUID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for i := 0; i < uniqueMaxAttempts; i++ {
+		uuid, err := g.gen.NewV4()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, dup := g.seen[uuid.bytes]; !dup {
+			g.seen[uuid.bytes] = struct{}{}
+			return uuid, nil
+		}
+	}
+
+	return nil, UUIDError{
+		Code:    1,
+		Message: getErrorMessage(1),
+		Detail:  fmt.Sprintf("no unused UUID after %d attempts", uniqueMaxAttempts),
+	}
+}
+
+// Context generates v4 UUIDs through a Rust context handle that buffers
+// random data between calls, so the entropy source is read far less often
+// than with NewV4. It is safe for concurrent use. Call Close to release the
+// handle; a finalizer releases it if Close is never called.
+type Context struct {
+	mu  sync.Mutex
+	ctx *C.UuidContext
+}
+
+func NewContext() *Context {
+	c := &Context{ctx: C.uuid_context_new()}
+	runtime.SetFinalizer(c, (*Context).Close)
+	return c
+}
+
+// NewV4 returns a v4 UUID generated from c. It fails with ErrInvalidParameter
+// once c has been closed.
+func (c *Context) NewV4() (*UUID, error) {
+	var uuid UUID
+	var cBytes [16]C.uint8_t
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ctx == nil {
+		return nil, UUIDError{
+			Code:    2,
+			Message: "context is closed",
+		}
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_generate_v4_ctx(c.ctx, &cBytes[0])
+	if result != 0 {
+		return nil, errorWithDetail(result)
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+// Close releases the Rust context. Calling it more than once is harmless.
+func (c *Context) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ctx != nil {
+		C.uuid_context_free(c.ctx)
+		c.ctx = nil
+		runtime.SetFinalizer(c, nil)
+	}
+
+	return nil
+}
+
+// NewV4Retry is like the package-level NewV4Retry but draws from g's source.
+func (g *Generator) NewV4Retry(attempts int, delay time.Duration) (*UUID, error) {
+	return retryEntropy(attempts, delay, g.NewV4)
+}
+
+// seededSource is a SplitMix64 pseudo-random generator.
+type seededSource struct {
+	state uint64
+}
+
+func (s *seededSource) Fill(buf []byte) error {
+	for i := 0; i < len(buf); i += 8 {
+		s.state += 0x9e3779b97f4a7c15
+		z := s.state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		z ^= z >> 31
+
+		for j := 0; j < 8 && i+j < len(buf); j++ {
+			buf[i+j] = byte(z >> (56 - 8*j))
+		}
+	}
+	return nil
+}
+
+func stampVersion(b *[16]byte, version byte) {
+	b[6] = (b[6] & 0x0f) | (version << 4)
+	b[8] = (b[8] & 0x3f) | 0x80
+}
+
+// String returns the canonical hyphenated form of u. It is formatted in pure
+// Go and cannot fail, so *UUID satisfies fmt.Stringer.
+func (u *UUID) String() string {
+	return string(appendCanonical(make([]byte, 0, 36), &u.bytes))
+}
+
+// Debug returns a multi-line, labeled breakdown of u for logs and
+// inspection: the canonical form, version, variant, and the timestamp and node
+// when the version carries them.
+func (u *UUID) Debug() string {
+	var b strings.Builder
+
+	version := VersionType(u.VersionFast())
+	fmt.Fprintf(&b, "UUID:      %s\n", u)
+	fmt.Fprintf(&b, "Version:   %d (%s)\n", uint8(version), version)
+	if variant, err := u.VariantName(); err == nil {
+		fmt.Fprintf(&b, "Variant:   %s\n", variant)
+	}
+	if timestamp, err := u.Timestamp(); err == nil {
+		fmt.Fprintf(&b, "Timestamp: %s\n", timestamp.UTC().Format(time.RFC3339Nano))
+	}
+	if node, err := u.Node(); err == nil {
+		fmt.Fprintf(&b, "Node:      %x\n", node[:])
+	}
+
+	return b.String()
+}
+
+// Format implements fmt.Formatter: %x and %X print the 32 hex digits without
+// hyphens in lower or upper case, %v, %s, and %q format the canonical form as
+// a string, and every other verb prints the canonical form. Width and flags
+// are honored, so %40v pads and %q quotes.
+func (u *UUID) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'x', 'X':
+		var buf [32]byte
+		hex.Encode(buf[:], u.bytes[:])
+		if verb == 'X' {
+			copy(buf[:], bytes.ToUpper(buf[:]))
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), buf[:])
+	case 'v', 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), u.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), u.String())
+	}
+}
+
+// StringChecked formats u through the Rust library, reporting any FFI error.
+func (u *UUID) StringChecked() (string, error) {
+	var cBytes [16]C.uint8_t
+	var buffer [37]C.char
+
+	for i := 0; i < 16; i++ {
+		cBytes[i] = C.uint8_t(u.bytes[i])
+	}
+
+	result := C.uuid_to_string(&cBytes[0], &buffer[0], 37)
+	if result != 0 {
+		return "", UUIDError{
+			Code:    int32(result),
+			Message: getErrorMessage(int32(result)),
+		}
+	}
+
+	return C.GoString(&buffer[0]), nil
+}
+
+// AppendString appends the canonical 36-character form of u to dst and
+// returns the extended slice, like strconv.AppendInt. It formats in pure Go
+// and does not allocate when dst has enough capacity.
+func (u *UUID) AppendString(dst []byte) []byte {
+	return appendCanonical(dst, &u.bytes)
+}
+
+// WriteStringArray writes the canonical 36-character form of u into dst,
+// without allocating or appending a trailing NUL, for fixed-width records.
+func (u *UUID) WriteStringArray(dst *[36]byte) {
+	encodeCanonical(dst, &u.bytes)
+}
+
+// appendCanonical formats b in the 8-4-4-4-12 form in pure Go, avoiding an
+// FFI call per UUID in hot paths.
+func appendCanonical(dst []byte, b *[16]byte) []byte {
+	var buf [36]byte
+	encodeCanonical(&buf, b)
+	return append(dst, buf[:]...)
+}
+
+func encodeCanonical(buf *[36]byte, b *[16]byte) {
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], b[10:16])
+}
+
+// GUIDString formats u like .NET's Guid.ToString with the given format
+// specifier, in lowercase: 'D' is the canonical form, 'N' has no hyphens, 'B'
+// is wrapped in braces, and 'P' in parentheses. Lowercase specifiers are
+// accepted too, as in .NET.
+func (u *UUID) GUIDString(format byte) (string, error) {
+	switch format {
+	case 'D', 'd':
+		return u.String(), nil
+	case 'N', 'n':
+		return hex.EncodeToString(u.bytes[:]), nil
+	case 'B', 'b':
+		return string(append(appendCanonical([]byte{'{'}, &u.bytes), '}')), nil
+	case 'P', 'p':
+		return string(append(appendCanonical([]byte{'('}, &u.bytes), ')')), nil
+	default:
+		return "", UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("unsupported GUID format specifier %q", format),
+		}
+	}
+}
+
+func (u *UUID) StringBraced() (string, error) {
+	return u.styledString(styleBraced)
+}
+
+func (u *UUID) StringSimple() (string, error) {
+	return u.styledString(styleSimple)
+}
+
+func (u *UUID) URN() (string, error) {
+	return u.styledString(styleURN)
+}
+
+func (u *UUID) StringUpper() (string, error) {
+	return u.styledString(styleCanonicalUpper)
+}
+
+func (u *UUID) styledString(style formatStyle) (string, error) {
+	var cBytes [16]C.uint8_t
+
+	for i := 0; i < 16; i++ {
+		cBytes[i] = C.uint8_t(u.bytes[i])
+	}
+
+	return rustString(func() *C.char {
+		return C.uuid_to_string_alloc(&cBytes[0], C.uint32_t(style))
+	})
+}
+
+// rustString copies a string allocated by the Rust library into Go memory and
+// releases the original, so callers never have to free C memory themselves.
+func rustString(call func() *C.char) (string, error) {
+	cString := call()
+	if cString == nil {
+		return "", UUIDError{
+			Code:    2,
+			Message: getErrorMessage(2),
+		}
+	}
+	defer C.uuid_free_string(cString)
+
+	return C.GoString(cString), nil
+}
+
+func (u *UUID) Bytes() [16]byte {
+	return u.bytes
+}
+
+// BytesLE returns u in the little-endian memory layout of a Windows GUID
+// struct: the first three fields (4, 2, and 2 bytes) are byte-swapped and
+// the last eight bytes are unchanged.
+func (u *UUID) BytesLE() [16]byte {
+	return swapGUIDFields(u.bytes)
+}
+
+func (u *UUID) Version() (uint8, error) {
+	var cBytes [16]C.uint8_t
+	var version, variant C.uint8_t
+
+	for i := 0; i < 16; i++ {
+		cBytes[i] = C.uint8_t(u.bytes[i])
+	}
+
+	result := C.uuid_get_info(&cBytes[0], &version, &variant)
+	if result != 0 {
+		return 0, UUIDError{
+			Code:    int32(result),
+			Message: getErrorMessage(int32(result)),
+		}
+	}
+
+	return uint8(version), nil
+}
+
+// VersionFast reads the version nibble in Go, without the FFI call made by
+// Version.
+func (u *UUID) VersionFast() uint8 {
+	return u.bytes[6] >> 4
+}
+
+func (u *UUID) Variant() (uint8, error) {
+	var cBytes [16]C.uint8_t
+	var version, variant C.uint8_t
+
+	for i := 0; i < 16; i++ {
+		cBytes[i] = C.uint8_t(u.bytes[i])
+	}
+
+	result := C.uuid_get_info(&cBytes[0], &version, &variant)
+	if result != 0 {
+		return 0, UUIDError{
+			Code:    int32(result),
+			Message: getErrorMessage(int32(result)),
+		}
+	}
+
+	return uint8(variant), nil
+}
+
+// gregorianOffset is the number of 100-nanosecond intervals between the
+// Gregorian epoch used by v1 and v6 UUIDs (1582-10-15) and the Unix epoch.
+const gregorianOffset = 0x01b21dd213814000
+
+func (u *UUID) Timestamp() (time.Time, error) {
+	var cBytes [16]C.uint8_t
+	var timestamp C.uint64_t
+	var hasTimestamp C.uint8_t
+
+	for i := 0; i < 16; i++ {
+		cBytes[i] = C.uint8_t(u.bytes[i])
+	}
+
+	result := C.uuid_get_timestamp(&cBytes[0], &timestamp, &hasTimestamp)
+	if result != 0 {
+		return time.Time{}, UUIDError{
+			Code:    int32(result),
+			Message: getErrorMessage(int32(result)),
+		}
+	}
+
+	version := u.VersionFast()
+	if hasTimestamp == 0 {
+		return time.Time{}, UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("UUID version %d does not carry a timestamp", version),
+		}
+	}
+
+	if version == 7 {
+		return time.UnixMilli(int64(timestamp)), nil
+	}
+
+	ticks := int64(timestamp) - gregorianOffset
+	return time.Unix(ticks/10000000, (ticks%10000000)*100), nil
+}
+
+// SameTimestamp reports whether u and other carry the same timestamp, such as
+// two v7 UUIDs generated within one millisecond. Both must be v1, v6, or v7.
+func (u *UUID) SameTimestamp(other *UUID) (bool, error) {
+	ut, err := u.Timestamp()
+	if err != nil {
+		return false, err
+	}
+
+	ot, err := other.Timestamp()
+	if err != nil {
+		return false, err
+	}
+
+	return ut.Equal(ot), nil
+}
+
+// TimeBucket returns the index of the d-wide time window, counted from the
+// Unix epoch, that the timestamp of a v1, v6, or v7 UUID falls in. Windows
+// before the epoch have negative indexes, so the window ending at the epoch
+// is -1. d must be at least one millisecond.
+func (u *UUID) TimeBucket(d time.Duration) (int64, error) {
+	if d.Milliseconds() <= 0 {
+		return 0, UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("time bucket width %s is shorter than 1ms", d),
+		}
+	}
+
+	timestamp, err := u.Timestamp()
+	if err != nil {
+		return 0, err
+	}
+
+	ms, width := timestamp.UnixMilli(), d.Milliseconds()
+	bucket := ms / width
+	if ms%width < 0 {
+		bucket--
+	}
+	return bucket, nil
+}
+
+// Domain returns the local domain of a v2 UUID.
+func (u *UUID) Domain() (uint8, error) {
+	if err := u.requireVersion(2); err != nil {
+		return 0, err
+	}
+
+	return u.bytes[9], nil
+}
+
+// ID returns the local identifier of a v2 UUID.
+func (u *UUID) ID() (uint32, error) {
+	if err := u.requireVersion(2); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint32(u.bytes[0:4]), nil
+}
+
+// ClockSequence returns the 14-bit clock sequence of a v1 or v6 UUID.
+func (u *UUID) ClockSequence() (uint16, error) {
+	if err := u.requireTimeBased("clock sequence"); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint16(u.bytes[8:10]) & 0x3fff, nil
+}
+
+// Node returns the 48-bit node identifier of a v1 or v6 UUID.
+func (u *UUID) Node() ([6]byte, error) {
+	var node [6]byte
+	if err := u.requireTimeBased("node"); err != nil {
+		return node, err
+	}
+
+	copy(node[:], u.bytes[10:])
+	return node, nil
+}
+
+// requireTimeBased reports an InvalidParameter error unless u is a v1 or v6
+// UUID, naming the requested field in the message.
+func (u *UUID) requireTimeBased(field string) error {
+	version, err := u.Version()
+	if err != nil {
+		return err
+	}
+
+	if version != 1 && version != 6 {
+		return UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("UUID version %d does not carry a %s", version, field),
+		}
+	}
+
+	return nil
+}
+
+// WithVersion returns a copy of u with the version nibble set to v and the
+// RFC 4122 variant bits set; every other bit is kept. Only versions 1 through
+// 8 are accepted.
+func (u *UUID) WithVersion(v uint8) (*UUID, error) {
+	if v < 1 || v > 8 {
+		return nil, UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("UUID version %d is not in the range 1-8", v),
+		}
+	}
+
+	converted := *u
+	stampVersion(&converted.bytes, v)
+	return &converted, nil
+}
+
+// RerandomizeV7 returns a copy of a v7 UUID with fresh random bits after the
+// 48-bit timestamp and 12-bit counter, so it sorts in the same position while
+// no longer matching the original.
+func (u *UUID) RerandomizeV7() (*UUID, error) {
+	if err := u.requireVersion(7); err != nil {
+		return nil, err
+	}
+
+	raw, err := RawRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	rerandomized := *u
+	copy(rerandomized.bytes[8:], raw[8:])
+	stampVersion(&rerandomized.bytes, 7)
+	return &rerandomized, nil
+}
+
+// ToV6 returns the v6 form of a v1 UUID by reordering its timestamp bits
+// most significant first. The clock sequence and node are kept unchanged.
+func (u *UUID) ToV6() (*UUID, error) {
+	if err := u.requireVersion(1); err != nil {
+		return nil, err
+	}
+
+	b := &u.bytes
+	ticks := uint64(binary.BigEndian.Uint32(b[0:4])) |
+		uint64(binary.BigEndian.Uint16(b[4:6]))<<32 |
+		uint64(binary.BigEndian.Uint16(b[6:8])&0x0fff)<<48
+
+	converted := *u
+	binary.BigEndian.PutUint32(converted.bytes[0:4], uint32(ticks>>28))
+	binary.BigEndian.PutUint16(converted.bytes[4:6], uint16(ticks>>12))
+	binary.BigEndian.PutUint16(converted.bytes[6:8], uint16(ticks&0x0fff)|0x6000)
+
+	return &converted, nil
+}
+
+// ToV1 is the inverse of ToV6.
+func (u *UUID) ToV1() (*UUID, error) {
+	if err := u.requireVersion(6); err != nil {
+		return nil, err
+	}
+
+	b := &u.bytes
+	ticks := uint64(binary.BigEndian.Uint32(b[0:4]))<<28 |
+		uint64(binary.BigEndian.Uint16(b[4:6]))<<12 |
+		uint64(binary.BigEndian.Uint16(b[6:8])&0x0fff)
+
+	converted := *u
+	binary.BigEndian.PutUint32(converted.bytes[0:4], uint32(ticks))
+	binary.BigEndian.PutUint16(converted.bytes[4:6], uint16(ticks>>32))
+	binary.BigEndian.PutUint16(converted.bytes[6:8], uint16(ticks>>48)&0x0fff|0x1000)
+
+	return &converted, nil
+}
+
+// requireVersion reports an InvalidParameter error unless u has version want.
+func (u *UUID) requireVersion(want uint8) error {
+	version, err := u.Version()
+	if err != nil {
+		return err
+	}
+
+	if version != want {
+		return UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("expected a version %d UUID, found version %d", want, version),
+		}
+	}
+
+	return nil
+}
+
+type VersionType uint8
+
+const (
+	VersionTimeBased     VersionType = 1
+	VersionDCESecurity   VersionType = 2
+	VersionNameBasedMD5  VersionType = 3
+	VersionRandom        VersionType = 4
+	VersionNameBasedSHA1 VersionType = 5
+	VersionV6            VersionType = 6
+	VersionV7            VersionType = 7
+	VersionV8            VersionType = 8
+)
+
+func (v VersionType) String() string {
+	switch v {
+	case VersionTimeBased:
+		return "time-based"
+	case VersionDCESecurity:
+		return "DCE Security"
+	case VersionNameBasedMD5:
+		return "name-based (MD5)"
+	case VersionRandom:
+		return "random"
+	case VersionNameBasedSHA1:
+		return "name-based (SHA-1)"
+	case VersionV6:
+		return "reordered time-based"
+	case VersionV7:
+		return "Unix time-based"
+	case VersionV8:
+		return "custom"
+	default:
+		return fmt.Sprintf("VersionType(%d)", uint8(v))
+	}
+}
+
+func (u *UUID) VersionName() (VersionType, error) {
+	version, err := u.Version()
+	if err != nil {
+		return 0, err
+	}
+
+	return VersionType(version), nil
+}
+
+// IsRFC4122 reports whether u uses the RFC 4122 variant, whose top two bits
+// of byte 8 are 10.
+func (u *UUID) IsRFC4122() bool {
+	return u.bytes[8]&0xc0 == 0x80
+}
+
+type VariantType int
+
+const (
+	VariantNCS VariantType = iota
+	VariantRFC4122
+	VariantMicrosoft
+	VariantFuture
+)
+
+func (v VariantType) String() string {
+	switch v {
+	case VariantNCS:
+		return "NCS"
+	case VariantRFC4122:
+		return "RFC 4122"
+	case VariantMicrosoft:
+		return "Microsoft"
+	case VariantFuture:
+		return "Future"
+	default:
+		return fmt.Sprintf("VariantType(%d)", int(v))
+	}
+}
+
+func (u *UUID) VariantName() (VariantType, error) {
+	variant, err := u.Variant()
+	if err != nil {
+		return 0, err
+	}
+
+	switch variant {
+	case 0:
+		return VariantNCS, nil
+	case 2:
+		return VariantRFC4122, nil
+	case 6:
+		return VariantMicrosoft, nil
+	default:
+		return VariantFuture, nil
+	}
+}
+
+func (u *UUID) Equal(other *UUID) (bool, error) {
+	var cBytes1, cBytes2 [16]C.uint8_t
+	var areEqual C.uint8_t
+
+	for i := 0; i < 16; i++ {
+		cBytes1[i] = C.uint8_t(u.bytes[i])
+		cBytes2[i] = C.uint8_t(other.bytes[i])
+	}
+
+	result := C.uuid_compare(&cBytes1[0], &cBytes2[0], &areEqual)
+	if result != 0 {
+		return false, UUIDError{
+			Code:    int32(result),
+			Message: getErrorMessage(int32(result)),
+		}
+	}
+
+	return areEqual == 1, nil
+}
+
+// EqualString parses s and reports whether it denotes u. A malformed s is
+// returned as the parse error.
+func (u *UUID) EqualString(s string) (bool, error) {
+	other, err := Parse(s)
+	if err != nil {
+		return false, err
+	}
+
+	return u.bytes == other.bytes, nil
+}
+
+func (u *UUID) EqualBytes(b [16]byte) bool {
+	return u.bytes == b
+}
+
+// EqualIgnoringVersion reports whether u and other are identical apart from
+// the version nibble and the two RFC 4122 variant bits, for example a v4 UUID
+// and the result of calling WithVersion(8) on it.
+func (u *UUID) EqualIgnoringVersion(other *UUID) bool {
+	a, b := u.bytes, other.bytes
+	a[6], b[6] = a[6]&0x0f, b[6]&0x0f
+	a[8], b[8] = a[8]&0x3f, b[8]&0x3f
+	return a == b
+}
+
+func (u *UUID) EqualConstantTime(other *UUID) bool {
+	return subtle.ConstantTimeCompare(u.bytes[:], other.bytes[:]) == 1
+}
+
+// PopCount returns the number of set bits in u. Over many v4 UUIDs it
+// averages about 63: half of the 122 random bits, plus the 2 set bits of the
+// fixed version and variant fields.
+func (u *UUID) PopCount() int {
+	hi, lo := u.Uint64Pair()
+	return bits.OnesCount64(hi) + bits.OnesCount64(lo)
+}
+
+func (u *UUID) Hash() uint64 {
+	const (
+		fnvOffsetBasis = 14695981039346656037
+		fnvPrime       = 1099511628211
+	)
+
+	hash := uint64(fnvOffsetBasis)
+	for _, b := range u.bytes {
+		hash ^= uint64(b)
+		hash *= fnvPrime
+	}
+	return hash
+}
+
+func (u *UUID) Compare(other *UUID) int {
+	return bytes.Compare(u.bytes[:], other.bytes[:])
+}
+
+// CompareTimeThenBytes orders every UUID carrying a timestamp (versions 1, 6,
+// and 7) before every UUID without one. Timestamped UUIDs are ordered by
+// timestamp, breaking ties by byte order; the rest are ordered by bytes as in
+// Compare. This is a total order, so it is safe to use with sort.Slice.
+func (u *UUID) CompareTimeThenBytes(other *UUID) int {
+	ut, uErr := u.Timestamp()
+	ot, oErr := other.Timestamp()
+	switch {
+	case uErr == nil && oErr == nil:
+		if c := ut.Compare(ot); c != 0 {
+			return c
+		}
+	case uErr == nil:
+		return -1
+	case oErr == nil:
+		return 1
+	}
+
+	return u.Compare(other)
+}
+
+func (u *UUID) Less(other *UUID) bool {
+	return u.Compare(other) < 0
+}
+
+// UUIDs implements sort.Interface, ordering by byte comparison.
+type UUIDs []*UUID
+
+func (s UUIDs) Len() int           { return len(s) }
+func (s UUIDs) Less(i, j int) bool { return s[i].Less(s[j]) }
+func (s UUIDs) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func Unique(uuids []*UUID) []*UUID {
+	seen := make(map[[16]byte]struct{}, len(uuids))
+	unique := make([]*UUID, 0, len(uuids))
+
+	for _, uuid := range uuids {
+		if _, ok := seen[uuid.bytes]; ok {
+			continue
+		}
+		seen[uuid.bytes] = struct{}{}
+		unique = append(unique, uuid)
+	}
+
+	return unique
+}
+
+// Strings formats each UUID in canonical form. A nil entry stops the
+// conversion with an InvalidParameter error naming its index.
+func Strings(uuids []*UUID) ([]string, error) {
+	strs := make([]string, len(uuids))
+
+	for i, uuid := range uuids {
+		if uuid == nil {
+			return nil, UUIDError{
+				Code:    2,
+				Message: fmt.Sprintf("UUID at index %d is nil", i),
+			}
+		}
+		strs[i] = uuid.String()
+	}
+
+	return strs, nil
+}
+
+func Parse(s string) (*UUID, error) {
+	var uuid UUID
+	var cBytes [16]C.uint8_t
+
+	cString := C.CString(s)
+	defer C.free(unsafe.Pointer(cString))
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	result := C.uuid_from_string(cString, C.size_t(len(s)), &cBytes[0])
+	if result != 0 {
+		err := errorWithDetail(result)
+		err.Message = fmt.Sprintf("%s %q", err.Message, s)
+		return nil, err
+	}
+
+	for i := 0; i < 16; i++ {
+		uuid.bytes[i] = byte(cBytes[i])
+	}
+
+	return &uuid, nil
+}
+
+// ParseWithVersion parses s and returns the UUID together with its version
+// nibble, read in Go without a second FFI call.
+func ParseWithVersion(s string) (*UUID, uint8, error) {
+	uuid, err := Parse(s)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return uuid, uuid.VersionFast(), nil
+}
+
+// ParseAll parses every string in ss, stopping at the first failure with an
+// error that names its index. Use ParseAllCollect to report every failure.
+func ParseAll(ss []string) ([]*UUID, error) {
+	uuids := make([]*UUID, len(ss))
+
+	for i, s := range ss {
+		uuid, err := parseAt(i, s)
+		if err != nil {
+			return nil, err
+		}
+		uuids[i] = uuid
+	}
+
+	return uuids, nil
+}
+
+// ParseAllCollect parses every string in ss. Entries that fail to parse are
+// left nil, and the returned error joins the failures of all of them.
+func ParseAllCollect(ss []string) ([]*UUID, error) {
+	uuids := make([]*UUID, len(ss))
+	var errs []error
+
+	for i, s := range ss {
+		uuid, err := parseAt(i, s)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		uuids[i] = uuid
+	}
+
+	return uuids, errors.Join(errs...)
+}
+
+// parseAt parses s, prefixing any error message with the index of s within
+// a batch.
+func parseAt(i int, s string) (*UUID, error) {
+	uuid, err := Parse(s)
+	if err != nil {
+		return nil, prefixError(err, fmt.Sprintf("index %d", i))
+	}
+
+	return uuid, nil
+}
+
+// prefixError prepends prefix to the message of err. A UUIDError keeps its
+// type and code; any other error is wrapped, so errors.Is still matches it.
+func prefixError(err error, prefix string) error {
+	var uuidErr UUIDError
+	if errors.As(err, &uuidErr) {
+		uuidErr.Message = fmt.Sprintf("%s: %s", prefix, uuidErr.Message)
+		return uuidErr
+	}
+
+	return fmt.Errorf("%s: %w", prefix, err)
+}
+
+// ParseStream parses newline-delimited UUIDs from r, skipping blank lines.
+// Parsing stops at the first malformed line, whose 1-based line number is
+// included in the error.
+func ParseStream(r io.Reader) ([]*UUID, error) {
+	var uuids []*UUID
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" {
+			continue
+		}
+
+		uuid, err := Parse(s)
+		if err != nil {
+			return nil, prefixError(err, fmt.Sprintf("line %d", line))
+		}
+		uuids = append(uuids, uuid)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return uuids, nil
+}
+
+func Validate(s string, expectedVersion uint8) error {
+	uuid, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	version, err := uuid.Version()
+	if err != nil {
+		return err
+	}
+	if version != expectedVersion {
+		return UUIDError{
+			Code:    4,
+			Message: fmt.Sprintf("expected version %d, got %d", expectedVersion, version),
+		}
+	}
+
+	variant, err := uuid.Variant()
+	if err != nil {
+		return err
+	}
+	if variant != 2 {
+		return UUIDError{
+			Code:    4,
+			Message: fmt.Sprintf("expected RFC 4122 variant 2, got %d", variant),
+		}
+	}
+
+	return nil
 }
 
-func NewV4() (*UUID, error) {
+func (u *UUID) Base64URL() string {
+	return base64.RawURLEncoding.EncodeToString(u.bytes[:])
+}
+
+func ParseBase64URL(s string) (*UUID, error) {
+	if len(s) != 22 {
+		return nil, UUIDError{
+			Code:    4,
+			Message: fmt.Sprintf("expected 22 Base64 characters, found %d", len(s)),
+		}
+	}
+
+	decoded, err := base64.RawURLEncoding.Strict().DecodeString(s)
+	if err != nil {
+		return nil, UUIDError{
+			Code:    4,
+			Message: fmt.Sprintf("invalid Base64 UUID %q", s),
+			Detail:  err.Error(),
+		}
+	}
+
 	var uuid UUID
-	var cBytes [16]C.uint8_t
+	copy(uuid.bytes[:], decoded)
+	return &uuid, nil
+}
 
-	result := C.uuid_generate_v4(&cBytes[0])
-	if result != 0 {
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func (u *UUID) Base32() string {
+	hi := binary.BigEndian.Uint64(u.bytes[:8])
+	lo := binary.BigEndian.Uint64(u.bytes[8:])
+
+	// 26 digits of 5 bits hold 130 bits, so the leading digit only carries
+	// the top 3 bits of the UUID.
+	var encoded [26]byte
+	for i := 25; i >= 0; i-- {
+		encoded[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(encoded[:])
+}
+
+func ParseBase32(s string) (*UUID, error) {
+	if len(s) != 26 {
 		return nil, UUIDError{
-			Code:    int32(result),
-			Message: getErrorMessage(int32(result)),
+			Code:    4,
+			Message: fmt.Sprintf("expected 26 Base32 characters, found %d", len(s)),
 		}
 	}
 
-	for i := 0; i < 16; i++ {
-		uuid.bytes[i] = byte(cBytes[i])
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		value, ok := crockfordValue(s[i])
+		if !ok {
+			return nil, UUIDError{
+				Code:    4,
+				Message: fmt.Sprintf("invalid Base32 character %q at position %d", s[i], i),
+			}
+		}
+		if i == 0 && value > 7 {
+			return nil, UUIDError{
+				Code:    4,
+				Message: fmt.Sprintf("Base32 value %q overflows 128 bits", s),
+			}
+		}
+
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(value)
 	}
 
+	var uuid UUID
+	binary.BigEndian.PutUint64(uuid.bytes[:8], hi)
+	binary.BigEndian.PutUint64(uuid.bytes[8:], lo)
 	return &uuid, nil
 }
 
-func (u *UUID) String() (string, error) {
-	var cBytes [16]C.uint8_t
-	var buffer [37]C.char
+// crockfordValue decodes a Crockford Base32 digit case-insensitively, mapping
+// the easily misread I and L to 1 and O to 0.
+func crockfordValue(c byte) (byte, bool) {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
 
-	for i := 0; i < 16; i++ {
-		cBytes[i] = C.uint8_t(u.bytes[i])
+	switch c {
+	case 'I', 'L':
+		return 1, true
+	case 'O':
+		return 0, true
 	}
 
-	result := C.uuid_to_string(&cBytes[0], &buffer[0], 37)
-	if result != 0 {
-		return "", UUIDError{
-			Code:    int32(result),
-			Message: getErrorMessage(int32(result)),
+	index := strings.IndexByte(crockfordAlphabet, c)
+	if index < 0 {
+		return 0, false
+	}
+	return byte(index), true
+}
+
+// crockfordCheckSymbols extends the Crockford alphabet with the five extra
+// symbols used for check values 32 through 36.
+const crockfordCheckSymbols = crockfordAlphabet + "*~$=U"
+
+// ShortID returns the 26-character Crockford Base32 form of u followed by a
+// Crockford mod 37 check symbol, which catches any single mistyped character.
+func (u *UUID) ShortID() string {
+	return u.Base32() + string(crockfordCheckSymbols[u.crockfordChecksum()])
+}
+
+// ParseShortID decodes a ShortID after verifying its check symbol.
+func ParseShortID(s string) (*UUID, error) {
+	if len(s) != 27 {
+		return nil, UUIDError{
+			Code:    4,
+			Message: fmt.Sprintf("expected 27 short ID characters, found %d", len(s)),
 		}
 	}
 
-	return C.GoString(&buffer[0]), nil
+	uuid, err := ParseBase32(s[:26])
+	if err != nil {
+		return nil, err
+	}
+
+	check := strings.IndexByte(crockfordCheckSymbols, strings.ToUpper(s[26:])[0])
+	if check < 0 || check != uuid.crockfordChecksum() {
+		return nil, UUIDError{
+			Code:    4,
+			Message: fmt.Sprintf("checksum mismatch in short ID %q", s),
+		}
+	}
+
+	return uuid, nil
 }
 
-func (u *UUID) Bytes() [16]byte {
-	return u.bytes
+// crockfordChecksum returns the 128-bit value of u modulo 37.
+func (u *UUID) crockfordChecksum() int {
+	sum := 0
+	for _, b := range u.bytes {
+		sum = (sum*256 + int(b)) % 37
+	}
+	return sum
 }
 
-func (u *UUID) Version() (uint8, error) {
-	var cBytes [16]C.uint8_t
-	var version, variant C.uint8_t
+func FromBytes(bytes [16]byte) *UUID {
+	return &UUID{bytes: bytes}
+}
 
-	for i := 0; i < 16; i++ {
-		cBytes[i] = C.uint8_t(u.bytes[i])
+// Uint64Pair returns the high and low 64 bits of u, read big-endian.
+func (u *UUID) Uint64Pair() (hi, lo uint64) {
+	return binary.BigEndian.Uint64(u.bytes[:8]), binary.BigEndian.Uint64(u.bytes[8:])
+}
+
+func FromUint64Pair(hi, lo uint64) *UUID {
+	var uuid UUID
+	binary.BigEndian.PutUint64(uuid.bytes[:8], hi)
+	binary.BigEndian.PutUint64(uuid.bytes[8:], lo)
+	return &uuid
+}
+
+// BigInt returns u as a 128-bit unsigned big-endian integer.
+func (u *UUID) BigInt() *big.Int {
+	return new(big.Int).SetBytes(u.bytes[:])
+}
+
+// FromBigInt is the inverse of BigInt. Negative values and values wider than
+// 128 bits are rejected with an InvalidParameter error.
+func FromBigInt(n *big.Int) (*UUID, error) {
+	if n.Sign() < 0 || n.BitLen() > 128 {
+		return nil, UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("integer %s is outside the 128-bit UUID range", n),
+		}
 	}
 
-	result := C.uuid_get_info(&cBytes[0], &version, &variant)
-	if result != 0 {
-		return 0, UUIDError{
-			Code:    int32(result),
-			Message: getErrorMessage(int32(result)),
+	var uuid UUID
+	n.FillBytes(uuid.bytes[:])
+	return &uuid, nil
+}
+
+// RandomInRange returns a UUID drawn uniformly from the byte values between
+// low and high inclusive, using the Rust CSPRNG with rejection sampling.
+func RandomInRange(low, high *UUID) (*UUID, error) {
+	if low.Compare(high) > 0 {
+		return nil, UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("range low %s is greater than high %s", low, high),
 		}
 	}
 
-	return uint8(version), nil
+	lowInt := low.BigInt()
+	span := new(big.Int).Sub(high.BigInt(), lowInt)
+	span.Add(span, big.NewInt(1))
+	// Keep only as many random bits as span needs; span is 2^128 for the
+	// full range, where every value is accepted.
+	var shift uint
+	if span.BitLen() <= 128 {
+		shift = uint(128 - span.BitLen())
+	}
+
+	for {
+		raw, err := RawRandom()
+		if err != nil {
+			return nil, err
+		}
+
+		offset := new(big.Int).SetBytes(raw[:])
+		offset.Rsh(offset, shift)
+		if offset.Cmp(span) < 0 {
+			return FromBigInt(offset.Add(offset, lowInt))
+		}
+	}
 }
 
-func (u *UUID) Variant() (uint8, error) {
-	var cBytes [16]C.uint8_t
-	var version, variant C.uint8_t
+// FromBytesLE is the inverse of BytesLE.
+func FromBytesLE(bytes [16]byte) *UUID {
+	return &UUID{bytes: swapGUIDFields(bytes)}
+}
 
-	for i := 0; i < 16; i++ {
-		cBytes[i] = C.uint8_t(u.bytes[i])
+// FromAny builds a UUID from a string, a [16]byte, or a []byte. A 16-byte
+// slice is taken as raw bytes; any other slice is parsed as text, such as the
+// 36-character canonical form.
+func FromAny(v interface{}) (*UUID, error) {
+	switch v := v.(type) {
+	case string:
+		return Parse(v)
+	case [16]byte:
+		return FromBytes(v), nil
+	case []byte:
+		if len(v) == 16 {
+			var uuid UUID
+			copy(uuid.bytes[:], v)
+			return &uuid, nil
+		}
+		return Parse(string(v))
+	default:
+		return nil, UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("unsupported UUID source type %T", v),
+		}
 	}
+}
 
-	result := C.uuid_get_info(&cBytes[0], &version, &variant)
-	if result != 0 {
-		return 0, UUIDError{
-			Code:    int32(result),
-			Message: getErrorMessage(int32(result)),
+// swapGUIDFields switches between the big-endian RFC 4122 layout and the
+// little-endian GUID layout. Applying it twice returns the input.
+func swapGUIDFields(b [16]byte) [16]byte {
+	b[0], b[1], b[2], b[3] = b[3], b[2], b[1], b[0]
+	b[4], b[5] = b[5], b[4]
+	b[6], b[7] = b[7], b[6]
+	return b
+}
+
+// Complement returns a new UUID with every bit of u flipped. The version and
+// variant are not restamped.
+func (u *UUID) Complement() *UUID {
+	var complement UUID
+	for i, b := range u.bytes {
+		complement.bytes[i] = ^b
+	}
+	return &complement
+}
+
+func (u *UUID) Clone() *UUID {
+	clone := *u
+	return &clone
+}
+
+// Wipe zeroes u in place, for UUIDs used as secret tokens. The XOR of the
+// bytes with themselves goes through crypto/subtle, which the compiler does
+// not elide, and leaves u equal to the nil UUID.
+func (u *UUID) Wipe() {
+	subtle.XORBytes(u.bytes[:], u.bytes[:], u.bytes[:])
+	runtime.KeepAlive(u)
+}
+
+func Nil() *UUID {
+	return &UUID{}
+}
+
+func (u *UUID) IsNil() bool {
+	return u.bytes == [16]byte{}
+}
+
+// IsStandardNamespace reports whether u is one of the RFC 4122 namespaces and,
+// if so, returns its name: "DNS", "URL", "OID", or "X500".
+func (u *UUID) IsStandardNamespace() (name string, ok bool) {
+	switch u.bytes {
+	case NamespaceDNS.bytes:
+		return "DNS", true
+	case NamespaceURL.bytes:
+		return "URL", true
+	case NamespaceOID.bytes:
+		return "OID", true
+	case NamespaceX500.bytes:
+		return "X500", true
+	default:
+		return "", false
+	}
+}
+
+func Max() *UUID {
+	return &UUID{bytes: [16]byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}}
+}
+
+func (u *UUID) IsMax() bool {
+	return u.bytes == Max().bytes
+}
+
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		u.bytes = [16]byte{}
+		return nil
+	}
+
+	var uuidStr string
+	if err := json.Unmarshal(data, &uuidStr); err != nil {
+		return UUIDError{
+			Code:    4,
+			Message: fmt.Sprintf("expected a JSON string for UUID, found %s", data),
 		}
 	}
 
-	return uint8(variant), nil
+	return u.scanString(uuidStr)
 }
 
-func (u *UUID) Equal(other *UUID) (bool, error) {
-	var cBytes1, cBytes2 [16]C.uint8_t
-	var areEqual C.uint8_t
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
 
-	for i := 0; i < 16; i++ {
-		cBytes1[i] = C.uint8_t(u.bytes[i])
-		cBytes2[i] = C.uint8_t(other.bytes[i])
+func (u *UUID) UnmarshalText(text []byte) error {
+	return u.scanString(string(text))
+}
+
+func (u UUID) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(u.String(), start)
+}
+
+func (u *UUID) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var uuidStr string
+	if err := d.DecodeElement(&uuidStr, &start); err != nil {
+		return err
 	}
 
-	result := C.uuid_compare(&cBytes1[0], &cBytes2[0], &areEqual)
-	if result != 0 {
-		return false, UUIDError{
-			Code:    int32(result),
-			Message: getErrorMessage(int32(result)),
+	return u.scanString(strings.TrimSpace(uuidStr))
+}
+
+func (u UUID) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: u.String()}, nil
+}
+
+func (u *UUID) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.scanString(attr.Value)
+}
+
+func (u UUID) MarshalBinary() ([]byte, error) {
+	return u.bytes[:], nil
+}
+
+func (u *UUID) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("expected 16 bytes of binary UUID data, found %d", len(data)),
 		}
 	}
 
-	return areEqual == 1, nil
+	copy(u.bytes[:], data)
+	return nil
 }
 
-func FromBytes(bytes [16]byte) *UUID {
-	return &UUID{bytes: bytes}
+func ReadFrom(r io.Reader) (*UUID, error) {
+	var uuid UUID
+	if _, err := io.ReadFull(r, uuid.bytes[:]); err != nil {
+		return nil, err
+	}
+
+	return &uuid, nil
+}
+
+func (u *UUID) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(u.bytes[:])
+	return int64(n), err
+}
+
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+func (u *UUID) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		u.bytes = [16]byte{}
+		return nil
+	case [16]byte:
+		u.bytes = src
+		return nil
+	case []byte:
+		if len(src) == 16 {
+			copy(u.bytes[:], src)
+			return nil
+		}
+		return u.scanString(string(src))
+	case string:
+		return u.scanString(src)
+	default:
+		return UUIDError{
+			Code:    2,
+			Message: fmt.Sprintf("unsupported Scan source type %T", src),
+		}
+	}
+}
+
+func (u *UUID) scanString(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	u.bytes = parsed.bytes
+	return nil
+}
+
+// SelfTest checks that the Rust library is linked and working by generating a
+// UUID, formatting and parsing it through the FFI, and comparing the result.
+func SelfTest() error {
+	uuid, err := NewV4()
+	if err != nil {
+		return err
+	}
+
+	uuidStr, err := uuid.StringChecked()
+	if err != nil {
+		return err
+	}
+
+	parsed, err := Parse(uuidStr)
+	if err != nil {
+		return err
+	}
+
+	equal, err := uuid.Equal(parsed)
+	if err != nil {
+		return err
+	}
+	if !equal || uuidStr != uuid.String() {
+		return UUIDError{
+			Code:    99,
+			Message: fmt.Sprintf("self-test round trip mismatch: generated %s, parsed %s", uuid, parsed),
+		}
+	}
+
+	return nil
+}
+
+// errorMessages holds messages added with RegisterErrorMessage.
+var errorMessages struct {
+	mu       sync.RWMutex
+	messages map[int32]string
+}
+
+// RegisterErrorMessage sets the message reported for an FFI error code, for
+// builds of the Rust library that return codes beyond the standard ones. It
+// can also replace the message for a standard code.
+func RegisterErrorMessage(code int32, msg string) {
+	errorMessages.mu.Lock()
+	defer errorMessages.mu.Unlock()
+
+	if errorMessages.messages == nil {
+		errorMessages.messages = make(map[int32]string)
+	}
+	errorMessages.messages[code] = msg
 }
 
 func getErrorMessage(code int32) string {
+	errorMessages.mu.RLock()
+	msg, ok := errorMessages.messages[code]
+	errorMessages.mu.RUnlock()
+	if ok {
+		return msg
+	}
+
 	switch code {
 	case 0:
 		return "Success"
@@ -143,6 +2299,8 @@ func getErrorMessage(code int32) string {
 		return "Invalid parameter (null pointer, invalid size, etc.)"
 	case 3:
 		return "Buffer too small for output"
+	case 4:
+		return "Invalid UUID string format"
 	case 99:
 		return "Unknown error"
 	default:
@@ -161,7 +2319,7 @@ func main() {
 		return
 	}
 
-	uuidStr, err := uuid.String()
+	uuidStr, err := uuid.StringChecked()
 	if err != nil {
 		fmt.Printf("   Error converting to string: %v\n", err)
 		return
@@ -192,7 +2350,7 @@ func main() {
 			continue
 		}
 
-		uuidStr, err := uuid.String()
+		uuidStr, err := uuid.StringChecked()
 		if err != nil {
 			fmt.Printf("   Error converting UUID %d to string: %v\n", i, err)
 			continue
@@ -216,9 +2374,9 @@ func main() {
 
 	uuid1Copy := FromBytes(uuid1.Bytes())
 
-	uuid1Str, _ := uuid1.String()
-	uuid2Str, _ := uuid2.String()
-	uuid1CopyStr, _ := uuid1Copy.String()
+	uuid1Str := uuid1.String()
+	uuid2Str := uuid2.String()
+	uuid1CopyStr := uuid1Copy.String()
 
 	fmt.Printf("   UUID 1: %s\n", uuid1Str)
 	fmt.Printf("   UUID 2: %s\n", uuid2Str)
@@ -259,7 +2417,7 @@ func main() {
 			continue
 		}
 
-		uuidStr, _ := uuid.String()
+		uuidStr := uuid.String()
 		fmt.Printf("   UUID %d: %s (v%d, variant %d)\n", i, uuidStr, version, variant)
 
 		if version != 4 {
@@ -271,6 +2429,48 @@ func main() {
 	}
 	fmt.Println("   All UUIDs have correct version (4) and variant (2)")
 
+	fmt.Println("\n5. Generating time-ordered UUID v7s:")
+	for i := 1; i <= 5; i++ {
+		uuid, err := NewV7()
+		if err != nil {
+			fmt.Printf("   Error generating UUID %d: %v\n", i, err)
+			continue
+		}
+
+		uuidStr := uuid.String()
+		fmt.Printf("   UUID %d: %s\n", i, uuidStr)
+	}
+
+	fmt.Println("\n6. Parsing UUID strings:")
+	for _, input := range []string{uuid1Str, "550e8400-e29b-41d4-a716-446655440000", "not-a-uuid"} {
+		parsed, err := Parse(input)
+		if err != nil {
+			fmt.Printf("   %q rejected: %v\n", input, err)
+			continue
+		}
+
+		parsedStr := parsed.String()
+		fmt.Printf("   %q parsed as %s\n", input, parsedStr)
+	}
+
+	fmt.Println("\n7. Generating name-based UUIDs:")
+	v5, err := NewV5(NamespaceDNS, []byte("python.org"))
+	if err != nil {
+		fmt.Printf("   Error generating UUID v5: %v\n", err)
+		return
+	}
+
+	v3, err := NewV3(NamespaceDNS, []byte("python.org"))
+	if err != nil {
+		fmt.Printf("   Error generating UUID v3: %v\n", err)
+		return
+	}
+
+	v5Str := v5.String()
+	v3Str := v3.String()
+	fmt.Printf("   v5(DNS, python.org): %s\n", v5Str)
+	fmt.Printf("   v3(DNS, python.org): %s\n", v3Str)
+
 	fmt.Println("\nGo integration example completed successfully!")
 	fmt.Println("The Rust UUID library is working correctly through FFI bindings.")
 }
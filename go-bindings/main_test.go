@@ -0,0 +1,2397 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// unixMillis returns the 48-bit Unix millisecond prefix of a v7 UUID.
+func unixMillis(u *UUID) int64 {
+	b := u.Bytes()
+	var buf [8]byte
+	copy(buf[2:], b[:6])
+	return int64(binary.BigEndian.Uint64(buf[:]))
+}
+
+func TestNewV7(t *testing.T) {
+	before := time.Now().UnixMilli()
+	var previous int64
+	for i := 0; i < 100; i++ {
+		uuid, err := NewV7()
+		if err != nil {
+			t.Fatalf("NewV7() error = %v", err)
+		}
+
+		version, err := uuid.Version()
+		if err != nil || version != 7 {
+			t.Errorf("Version() = %d, %v; want 7", version, err)
+		}
+		variant, err := uuid.Variant()
+		if err != nil || variant != 2 {
+			t.Errorf("Variant() = %d, %v; want 2", variant, err)
+		}
+
+		ms := unixMillis(uuid)
+		if ms < previous {
+			t.Errorf("timestamp went backwards: %d after %d", ms, previous)
+		}
+		previous = ms
+	}
+
+	if after := time.Now().UnixMilli(); previous < before || previous > after {
+		t.Errorf("timestamp %d outside [%d, %d]", previous, before, after)
+	}
+}
+
+func mustParse(t testing.TB, s string) *UUID {
+	t.Helper()
+
+	uuid, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", s, err)
+	}
+	return uuid
+}
+
+func TestParse(t *testing.T) {
+	tests := []string{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"00000000-0000-0000-0000-000000000000",
+	}
+	for _, input := range tests {
+		uuid := mustParse(t, input)
+		if got := uuid.String(); got != input {
+			t.Errorf("Parse(%q).String() = %q", input, got)
+		}
+	}
+
+	uuid := mustParse(t, "550e8400-e29b-41d4-a716-446655440000")
+	if version, _ := uuid.Version(); version != 4 {
+		t.Errorf("Version() = %d, want 4", version)
+	}
+}
+
+func TestParseRejectsInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"not-a-uuid",
+		"550e8400-e29b-41d4-a716-44665544000",
+		"550e8400-e29b-41d4-a716-4466554400000",
+		"550e8400-e29b-41d4-a716-44665544000g",
+		"550e8400+e29b-41d4-a716-446655440000",
+	}
+	for _, input := range tests {
+		_, err := Parse(input)
+		uuidErr, ok := err.(UUIDError)
+		if !ok || uuidErr.Code != 4 {
+			t.Errorf("Parse(%q) error = %v, want code 4", input, err)
+		}
+	}
+}
+
+func TestStringBraced(t *testing.T) {
+	uuid := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	got, err := uuid.StringBraced()
+	if err != nil || got != "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}" {
+		t.Errorf("StringBraced() = %q, %v", got, err)
+	}
+}
+
+func mustNewV4(t testing.TB) *UUID {
+	t.Helper()
+
+	uuid, err := NewV4()
+	if err != nil {
+		t.Fatalf("NewV4() error = %v", err)
+	}
+	return uuid
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	type record struct {
+		ID     UUID  `json:"id"`
+		Parent *UUID `json:"parent"`
+	}
+
+	in := record{ID: *mustNewV4(t), Parent: mustNewV4(t)}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := fmt.Sprintf(`{"id":"%s","parent":"%s"}`, &in.ID, in.Parent)
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var out record
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if out.ID != in.ID || *out.Parent != *in.Parent {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", out, in)
+	}
+}
+
+func TestJSONNull(t *testing.T) {
+	uuid := *mustNewV4(t)
+	if err := uuid.UnmarshalJSON([]byte("null")); err != nil {
+		t.Fatalf("UnmarshalJSON(null) error = %v", err)
+	}
+	if uuid.Bytes() != [16]byte{} {
+		t.Errorf("UnmarshalJSON(null) = %x, want the nil UUID", uuid.Bytes())
+	}
+
+	var out struct {
+		Parent *UUID `json:"parent"`
+	}
+	if err := json.Unmarshal([]byte(`{"parent":null}`), &out); err != nil || out.Parent != nil {
+		t.Errorf("json.Unmarshal(null pointer) = %v, %v; want nil, nil", out.Parent, err)
+	}
+}
+
+func TestJSONRejectsInvalid(t *testing.T) {
+	for _, input := range []string{`42`, `"bogus"`, `{}`} {
+		var uuid UUID
+		if err := json.Unmarshal([]byte(input), &uuid); err == nil {
+			t.Errorf("json.Unmarshal(%s) error = nil, want an error", input)
+		}
+	}
+}
+
+// errorCode returns the code of a UUIDError in err's chain, or 0 if there is
+// none.
+func errorCode(err error) int32 {
+	var uuidErr UUIDError
+	if errors.As(err, &uuidErr) {
+		return uuidErr.Code
+	}
+	return 0
+}
+
+func TestScan(t *testing.T) {
+	want := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	raw := want.Bytes()
+
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{"string", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"text bytes", []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8")},
+		{"raw bytes", raw[:]},
+		{"array", raw},
+	}
+	for _, tt := range tests {
+		var uuid UUID
+		if err := uuid.Scan(tt.src); err != nil || uuid != *want {
+			t.Errorf("Scan(%s) = %x, %v; want %x", tt.name, uuid.Bytes(), err, raw)
+		}
+	}
+
+	uuid := *want
+	if err := uuid.Scan(nil); err != nil || uuid.Bytes() != [16]byte{} {
+		t.Errorf("Scan(nil) = %x, %v; want the nil UUID", uuid.Bytes(), err)
+	}
+	if err := uuid.Scan(42); errorCode(err) != 2 {
+		t.Errorf("Scan(int) error = %v, want code 2", err)
+	}
+	if err := uuid.Scan("bogus"); errorCode(err) != 4 {
+		t.Errorf("Scan(bogus) error = %v, want code 4", err)
+	}
+}
+
+func TestValue(t *testing.T) {
+	uuid := mustNewV4(t)
+	want := uuid.String()
+
+	value, err := uuid.Value()
+	if err != nil || value != want {
+		t.Errorf("Value() = %v, %v; want %s", value, err, want)
+	}
+}
+
+func TestNewV5(t *testing.T) {
+	tests := []struct {
+		namespace *UUID
+		name      string
+		want      string
+	}{
+		{NamespaceDNS, "python.org", "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{NamespaceURL, "http://python.org/", "4c565f0d-3f5a-5890-b41b-20cf47701c5e"},
+	}
+	for _, tt := range tests {
+		uuid, err := NewV5(tt.namespace, []byte(tt.name))
+		if err != nil {
+			t.Fatalf("NewV5(%q) error = %v", tt.name, err)
+		}
+		if got := uuid.String(); got != tt.want {
+			t.Errorf("NewV5(%q) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	uuid, err := NewV5(NamespaceDNS, nil)
+	if err != nil {
+		t.Fatalf("NewV5(empty name) error = %v", err)
+	}
+	if version, _ := uuid.Version(); version != 5 {
+		t.Errorf("NewV5(empty name) version = %d, want 5", version)
+	}
+}
+
+func TestNewV3(t *testing.T) {
+	tests := []struct {
+		namespace *UUID
+		name      string
+		want      string
+	}{
+		{NamespaceDNS, "python.org", "6fa459ea-ee8a-3ca4-894e-db77e160355e"},
+		{NamespaceURL, "http://python.org/", "9fe8e8c4-aaa8-32a9-a55c-4535a88b748d"},
+	}
+	for _, tt := range tests {
+		uuid, err := NewV3(tt.namespace, []byte(tt.name))
+		if err != nil {
+			t.Fatalf("NewV3(%q) error = %v", tt.name, err)
+		}
+		if got := uuid.String(); got != tt.want {
+			t.Errorf("NewV3(%q) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNamespaceStrings(t *testing.T) {
+	tests := []struct {
+		namespace *UUID
+		want      string
+	}{
+		{NamespaceDNS, "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{NamespaceURL, "6ba7b811-9dad-11d1-80b4-00c04fd430c8"},
+		{NamespaceOID, "6ba7b812-9dad-11d1-80b4-00c04fd430c8"},
+		{NamespaceX500, "6ba7b814-9dad-11d1-80b4-00c04fd430c8"},
+	}
+	for _, tt := range tests {
+		if got := tt.namespace.String(); got != tt.want {
+			t.Errorf("namespace = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestNil(t *testing.T) {
+	if !Nil().IsNil() {
+		t.Error("Nil().IsNil() = false")
+	}
+	if got := Nil().String(); got != "00000000-0000-0000-0000-000000000000" {
+		t.Errorf("Nil() = %s", got)
+	}
+	if mustNewV4(t).IsNil() {
+		t.Error("NewV4().IsNil() = true")
+	}
+}
+
+func TestMax(t *testing.T) {
+	if got := Max().String(); got != "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Errorf("Max() = %s", got)
+	}
+	if !Max().IsMax() {
+		t.Error("Max().IsMax() = false")
+	}
+
+	nearlyMax := Max().Bytes()
+	nearlyMax[15] = 0xfe
+	for _, uuid := range []*UUID{Nil(), FromBytes(nearlyMax), mustNewV4(t)} {
+		if uuid.IsMax() {
+			t.Errorf("%x.IsMax() = true", uuid.Bytes())
+		}
+	}
+}
+
+func TestNewV4Batch(t *testing.T) {
+	uuids, err := NewV4Batch(100)
+	if err != nil || len(uuids) != 100 {
+		t.Fatalf("NewV4Batch(100) = %d UUIDs, %v", len(uuids), err)
+	}
+
+	seen := make(map[UUID]bool)
+	for _, uuid := range uuids {
+		if version, _ := uuid.Version(); version != 4 {
+			t.Errorf("NewV4Batch() version = %d, want 4", version)
+		}
+		if seen[*uuid] {
+			t.Errorf("NewV4Batch() repeated %x", uuid.Bytes())
+		}
+		seen[*uuid] = true
+	}
+
+	if uuids, err := NewV4Batch(0); err != nil || len(uuids) != 0 {
+		t.Errorf("NewV4Batch(0) = %v, %v; want an empty slice", uuids, err)
+	}
+	if _, err := NewV4Batch(-1); errorCode(err) != 2 {
+		t.Errorf("NewV4Batch(-1) error = %v, want code 2", err)
+	}
+}
+
+func TestStringSimple(t *testing.T) {
+	uuid := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	got, err := uuid.StringSimple()
+	if err != nil || got != "6ba7b8109dad11d180b400c04fd430c8" {
+		t.Errorf("StringSimple() = %q, %v", got, err)
+	}
+}
+
+func TestURN(t *testing.T) {
+	uuid := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	urn, err := uuid.URN()
+	if err != nil || urn != "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Fatalf("URN() = %q, %v", urn, err)
+	}
+	if parsed := mustParse(t, strings.TrimPrefix(urn, "urn:uuid:")); *parsed != *uuid {
+		t.Errorf("Parse(URN() without prefix) = %x, want %x", parsed.Bytes(), uuid.Bytes())
+	}
+}
+
+func TestLessMatchesStringOrder(t *testing.T) {
+	uuids, err := NewV4Batch(50)
+	if err != nil {
+		t.Fatalf("NewV4Batch() error = %v", err)
+	}
+
+	strs := make([]string, len(uuids))
+	for i, uuid := range uuids {
+		strs[i] = uuid.String()
+	}
+	sort.Strings(strs)
+	sort.Slice(uuids, func(i, j int) bool { return uuids[i].Less(uuids[j]) })
+
+	for i, uuid := range uuids {
+		if uuid.String() != strs[i] {
+			t.Fatalf("sorted[%d] = %s, want %s", i, uuid, strs[i])
+		}
+	}
+
+	if Nil().Less(Nil()) || !Nil().Less(Max()) || Max().Less(Nil()) {
+		t.Error("Less() is not a strict order on Nil and Max")
+	}
+}
+
+// failingSource fails its first failures calls, then fills buffers with
+// incrementing bytes.
+type failingSource struct {
+	failures int
+	calls    int
+}
+
+func (s *failingSource) Fill(buf []byte) error {
+	s.calls++
+	if s.calls <= s.failures {
+		return errors.New("source unavailable")
+	}
+	for i := range buf {
+		buf[i] = byte(s.calls + i)
+	}
+	return nil
+}
+
+func TestSeededGeneratorIsReproducible(t *testing.T) {
+	first, second, other := NewSeededGenerator(42), NewSeededGenerator(42), NewSeededGenerator(43)
+
+	for i := 0; i < 10; i++ {
+		a, errA := first.NewV4()
+		b, errB := second.NewV4()
+		c, errC := other.NewV4()
+		if errA != nil || errB != nil || errC != nil {
+			t.Fatalf("NewV4() errors = %v, %v, %v", errA, errB, errC)
+		}
+		if *a != *b {
+			t.Errorf("same seed gave %x and %x", a.Bytes(), b.Bytes())
+		}
+		if *a == *c {
+			t.Errorf("different seeds both gave %x", a.Bytes())
+		}
+		version, _ := a.Version()
+		variant, _ := a.Variant()
+		if version != 4 || variant != 2 {
+			t.Errorf("seeded UUID has version %d, variant %d; want 4, 2", version, variant)
+		}
+	}
+}
+
+func TestGeneratorSourceErrors(t *testing.T) {
+	g := NewGenerator(&failingSource{failures: 1})
+
+	_, err := g.NewV4()
+	var uuidErr UUIDError
+	if !errors.As(err, &uuidErr) || uuidErr.Code != 1 || uuidErr.Detail != "source unavailable" {
+		t.Errorf("NewV4() error = %#v, want code 1 with the source's detail", err)
+	}
+	if _, err := g.NewV4(); err != nil {
+		t.Errorf("NewV4() after recovery error = %v", err)
+	}
+}
+
+func TestGeneratorIsConcurrencySafe(t *testing.T) {
+	for name, g := range map[string]*Generator{"default": NewGenerator(nil), "seeded": NewSeededGenerator(1)} {
+		var wg sync.WaitGroup
+		results := make(chan *UUID, 100)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					uuid, err := g.NewV4()
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					results <- uuid
+				}
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		seen := make(map[UUID]bool)
+		for uuid := range results {
+			if seen[*uuid] {
+				t.Errorf("%s generator repeated %x", name, uuid.Bytes())
+			}
+			seen[*uuid] = true
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := mustParse(t, "00000000-0000-4000-8000-000000000001")
+	b := mustParse(t, "00000000-0000-4000-8000-000000000002")
+
+	tests := []struct {
+		name     string
+		u, other *UUID
+		want     int
+	}{
+		{"a < b", a, b, -1},
+		{"b > a", b, a, 1},
+		{"a == copy", a, FromBytes(a.Bytes()), 0},
+		{"nil < a", Nil(), a, -1},
+		{"max > a", Max(), a, 1},
+		{"nil < max", Nil(), Max(), -1},
+		{"max > nil", Max(), Nil(), 1},
+		{"nil == nil", Nil(), Nil(), 0},
+		{"max == max", Max(), Max(), 0},
+	}
+	for _, tt := range tests {
+		if got := tt.u.Compare(tt.other); got != tt.want {
+			t.Errorf("Compare(%s) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	// The RFC 9562 test vectors, both created at 2022-02-22 19:22:22 UTC.
+	want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	for _, s := range []string{
+		"c232ab00-9414-11ec-b3c8-9f6bdeced846",
+		"1ec9414c-232a-6b00-b3c8-9f6bdeced846",
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+	} {
+		got, err := mustParse(t, s).Timestamp()
+		if err != nil || !got.Equal(want) {
+			t.Errorf("Timestamp(%s) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+
+	before := time.Now().Truncate(time.Millisecond)
+	v7, err := NewV7()
+	if err != nil {
+		t.Fatalf("NewV7() error = %v", err)
+	}
+	got, err := v7.Timestamp()
+	if err != nil || got.Before(before) || got.After(time.Now()) {
+		t.Errorf("NewV7().Timestamp() = %v, %v; want about %v", got, err, before)
+	}
+
+	if _, err := mustNewV4(t).Timestamp(); errorCode(err) != 2 {
+		t.Errorf("Timestamp(v4) error = %v, want code 2", err)
+	}
+}
+
+func TestNewV1(t *testing.T) {
+	before := time.Now()
+	seen := make(map[UUID]bool)
+	for i := 0; i < 100; i++ {
+		uuid, err := NewV1()
+		if err != nil {
+			t.Fatalf("NewV1() error = %v", err)
+		}
+		version, _ := uuid.Version()
+		variant, _ := uuid.Variant()
+		if version != 1 || variant != 2 {
+			t.Errorf("NewV1() has version %d, variant %d; want 1, 2", version, variant)
+		}
+		if seen[*uuid] {
+			t.Errorf("NewV1() repeated %x", uuid.Bytes())
+		}
+		seen[*uuid] = true
+
+		ts, err := uuid.Timestamp()
+		if err != nil || ts.Before(before.Truncate(time.Microsecond)) || ts.After(time.Now()) {
+			t.Errorf("NewV1().Timestamp() = %v, %v; want about %v", ts, err, before)
+		}
+	}
+}
+
+func TestNewV1WithNode(t *testing.T) {
+	node := [6]byte{0x02, 0x00, 0x5e, 0x10, 0x00, 0x01}
+	uuid, err := NewV1WithNode(node)
+	if err != nil {
+		t.Fatalf("NewV1WithNode() error = %v", err)
+	}
+
+	b := uuid.Bytes()
+	if got := [6]byte(b[10:]); got != node {
+		t.Errorf("NewV1WithNode() node = %x, want %x", got, node)
+	}
+	if version, _ := uuid.Version(); version != 1 {
+		t.Errorf("NewV1WithNode() version = %d, want 1", version)
+	}
+}
+
+func TestBase64URL(t *testing.T) {
+	tests := []struct {
+		uuid *UUID
+		want string
+	}{
+		{Nil(), "AAAAAAAAAAAAAAAAAAAAAA"},
+		{Max(), "_____________________w"},
+	}
+	for _, tt := range tests {
+		if got := tt.uuid.Base64URL(); got != tt.want {
+			t.Errorf("Base64URL(%x) = %q, want %q", tt.uuid.Bytes(), got, tt.want)
+		}
+	}
+
+	uuids, err := NewV4Batch(100)
+	if err != nil {
+		t.Fatalf("NewV4Batch() error = %v", err)
+	}
+	for _, uuid := range uuids {
+		encoded := uuid.Base64URL()
+		decoded, err := ParseBase64URL(encoded)
+		if err != nil || *decoded != *uuid {
+			t.Fatalf("ParseBase64URL(%q) = %v, %v; want %x", encoded, decoded, err, uuid.Bytes())
+		}
+	}
+
+	for _, input := range []string{"short", "AAAAAAAAAAAAAAAAAAAAA+", "AAAAAAAAAAAAAAAAAAAAAAA", "_____________________x"} {
+		if _, err := ParseBase64URL(input); errorCode(err) != 4 {
+			t.Errorf("ParseBase64URL(%q) error = %v, want code 4", input, err)
+		}
+	}
+}
+
+func TestBase32(t *testing.T) {
+	if got := Nil().Base32(); got != "00000000000000000000000000" {
+		t.Errorf("Nil().Base32() = %q", got)
+	}
+	if got := Max().Base32(); got != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Errorf("Max().Base32() = %q", got)
+	}
+
+	uuid := mustParse(t, "01890a5d-ac96-774b-bcce-b302099a8057")
+	encoded := uuid.Base32()
+	if len(encoded) != 26 {
+		t.Fatalf("Base32() = %q, want 26 characters", encoded)
+	}
+	for _, input := range []string{encoded, strings.ToLower(encoded)} {
+		decoded, err := ParseBase32(input)
+		if err != nil || *decoded != *uuid {
+			t.Errorf("ParseBase32(%q) = %v, %v; want %x", input, decoded, err, uuid.Bytes())
+		}
+	}
+
+	for _, input := range []string{"U" + encoded[1:], encoded[:25] + "!", "8" + encoded[1:], encoded[:25]} {
+		if _, err := ParseBase32(input); errorCode(err) != 4 {
+			t.Errorf("ParseBase32(%q) error = %v, want code 4", input, err)
+		}
+	}
+}
+
+func TestTextMarshaler(t *testing.T) {
+	var _ encoding.TextMarshaler = UUID{}
+	var _ encoding.TextUnmarshaler = &UUID{}
+
+	uuid := mustNewV4(t)
+	want := uuid.String()
+	text, err := uuid.MarshalText()
+	if err != nil || string(text) != want {
+		t.Fatalf("MarshalText() = %q, %v; want %q", text, err, want)
+	}
+
+	var decoded UUID
+	if err := decoded.UnmarshalText(text); err != nil || decoded != *uuid {
+		t.Errorf("UnmarshalText() = %x, %v; want %x", decoded.Bytes(), err, uuid.Bytes())
+	}
+	if err := decoded.UnmarshalText([]byte("bogus")); errorCode(err) != 4 {
+		t.Errorf("UnmarshalText(bogus) error = %v, want code 4", err)
+	}
+
+}
+
+func TestValidate(t *testing.T) {
+	v4 := mustNewV4(t).String()
+	v1, err := NewV1()
+	if err != nil {
+		t.Fatalf("NewV1() error = %v", err)
+	}
+
+	tests := []struct {
+		input   string
+		version uint8
+		wantErr bool
+	}{
+		{v4, 4, false},
+		{v1.String(), 1, false},
+		{v1.String(), 4, true},
+		{v4, 7, true},
+		{"6ba7b810-9dad-41d1-c0b4-00c04fd430c8", 4, true},
+		{"bogus", 4, true},
+	}
+	for _, tt := range tests {
+		err := Validate(tt.input, tt.version)
+		if tt.wantErr && errorCode(err) != 4 || !tt.wantErr && err != nil {
+			t.Errorf("Validate(%q, %d) error = %v, want error %v", tt.input, tt.version, err, tt.wantErr)
+		}
+	}
+}
+
+// shortWriter accepts limit writes, then fails.
+type shortWriter struct {
+	limit int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	if w.limit == 0 {
+		return 0, io.ErrShortWrite
+	}
+	w.limit--
+	return len(p), nil
+}
+
+func TestStreamV4(t *testing.T) {
+	const count = batchChunkSize + 10
+
+	var buf bytes.Buffer
+	n, err := StreamV4(&buf, count, "\n")
+	if err != nil || n != count {
+		t.Fatalf("StreamV4() = %d, %v", n, err)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) != count {
+		t.Fatalf("StreamV4() wrote %d lines, want %d", len(lines), count)
+	}
+	for _, line := range lines {
+		if err := Validate(line, 4); err != nil {
+			t.Fatalf("StreamV4() wrote %q: %v", line, err)
+		}
+	}
+
+	n, err = StreamV4(&shortWriter{limit: 3}, 10, ",")
+	if n != 3 || !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("StreamV4(short writer) = %d, %v; want 3, io.ErrShortWrite", n, err)
+	}
+}
+
+func TestHash(t *testing.T) {
+	uuids, err := NewV4Batch(10000)
+	if err != nil {
+		t.Fatalf("NewV4Batch() error = %v", err)
+	}
+
+	hashes := make(map[uint64]bool, len(uuids))
+	for _, uuid := range uuids {
+		b := uuid.Bytes()
+		want := fnv.New64a()
+		want.Write(b[:])
+		if got := uuid.Hash(); got != want.Sum64() {
+			t.Fatalf("Hash(%x) = %#x, want FNV-1a %#x", b, got, want.Sum64())
+		}
+		hashes[uuid.Hash()] = true
+	}
+	if len(hashes) != len(uuids) {
+		t.Errorf("%d distinct hashes for %d UUIDs", len(hashes), len(uuids))
+	}
+}
+
+func TestUUIDAsMapKey(t *testing.T) {
+	uuid := mustNewV4(t)
+	m := map[UUID]int{*uuid: 1}
+	if m[*FromBytes(uuid.Bytes())] != 1 {
+		t.Error("an equal UUID did not find the map entry")
+	}
+}
+
+func TestUnique(t *testing.T) {
+	a, b := mustNewV4(t), mustNewV4(t)
+	input := []*UUID{a, b, a, FromBytes(b.Bytes()), Nil(), Nil()}
+
+	unique := Unique(input)
+	if len(unique) != 3 || unique[0] != a || unique[1] != b || !unique[2].IsNil() {
+		t.Errorf("Unique() = %v, want [a b nil]", unique)
+	}
+	if got := Unique(nil); len(got) != 0 {
+		t.Errorf("Unique(nil) = %v, want empty", got)
+	}
+}
+
+func TestReadFrom(t *testing.T) {
+	uuid := mustNewV4(t)
+	raw := uuid.Bytes()
+
+	got, err := ReadFrom(bytes.NewReader(raw[:]))
+	if err != nil || *got != *uuid {
+		t.Errorf("ReadFrom(16 bytes) = %v, %v; want %x", got, err, raw)
+	}
+
+	if _, err := ReadFrom(bytes.NewReader(raw[:10])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadFrom(10 bytes) error = %v, want io.ErrUnexpectedEOF", err)
+	}
+	if _, err := ReadFrom(bytes.NewReader(nil)); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadFrom(empty) error = %v, want io.EOF", err)
+	}
+
+	r := bytes.NewReader(append(raw[:], 0xaa, 0xbb))
+	got, err = ReadFrom(r)
+	if err != nil || *got != *uuid || r.Len() != 2 {
+		t.Errorf("ReadFrom(18 bytes) = %v, %v with %d left; want %x with 2 left", got, err, r.Len(), raw)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	uuid := mustNewV4(t)
+
+	var buf bytes.Buffer
+	n, err := uuid.WriteTo(&buf)
+	raw := uuid.Bytes()
+	if err != nil || n != 16 || !bytes.Equal(buf.Bytes(), raw[:]) {
+		t.Errorf("WriteTo() = %d, %v; wrote %x", n, err, buf.Bytes())
+	}
+}
+
+func TestEqualVariants(t *testing.T) {
+	a := mustNewV4(t)
+	pairs := [][2]*UUID{{a, FromBytes(a.Bytes())}, {a, mustNewV4(t)}, {Nil(), Max()}, {Nil(), Nil()}}
+
+	for _, pair := range pairs {
+		want, err := pair[0].Equal(pair[1])
+		if err != nil {
+			t.Fatalf("Equal() error = %v", err)
+		}
+		if got := pair[0].EqualConstantTime(pair[1]); got != want {
+			t.Errorf("EqualConstantTime(%x, %x) = %v, want %v", pair[0].Bytes(), pair[1].Bytes(), got, want)
+		}
+		if got := pair[0].EqualBytes(pair[1].Bytes()); got != want {
+			t.Errorf("EqualBytes(%x, %x) = %v, want %v", pair[0].Bytes(), pair[1].Bytes(), got, want)
+		}
+	}
+}
+
+func TestVariantName(t *testing.T) {
+	tests := []struct {
+		uuid string
+		want VariantType
+		name string
+	}{
+		{"6ba7b810-9dad-11d1-00b4-00c04fd430c8", VariantNCS, "NCS"},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", VariantRFC4122, "RFC 4122"},
+		{"6ba7b810-9dad-11d1-c0b4-00c04fd430c8", VariantMicrosoft, "Microsoft"},
+		{"6ba7b810-9dad-11d1-e0b4-00c04fd430c8", VariantFuture, "Future"},
+	}
+	for _, tt := range tests {
+		got, err := mustParse(t, tt.uuid).VariantName()
+		if err != nil || got != tt.want || got.String() != tt.name {
+			t.Errorf("VariantName(%s) = %v, %v; want %v", tt.uuid, got, err, tt.want)
+		}
+	}
+
+	if got, _ := mustNewV4(t).VariantName(); got != VariantRFC4122 {
+		t.Errorf("NewV4().VariantName() = %v, want VariantRFC4122", got)
+	}
+}
+
+func TestNewV8(t *testing.T) {
+	data := [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	uuid, err := NewV8(data)
+	if err != nil {
+		t.Fatalf("NewV8() error = %v", err)
+	}
+
+	got := uuid.Bytes()
+	for i := range data {
+		switch i {
+		case 6:
+			if got[i] != 0x80|data[i]&0x0f {
+				t.Errorf("byte 6 = %#x, want version 8 over %#x", got[i], data[i])
+			}
+		case 8:
+			if got[i] != 0x80|data[i]&0x3f {
+				t.Errorf("byte 8 = %#x, want the RFC 4122 variant over %#x", got[i], data[i])
+			}
+		default:
+			if got[i] != data[i] {
+				t.Errorf("byte %d = %#x, want %#x", i, got[i], data[i])
+			}
+		}
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	uuid := mustNewV4(t)
+	want := uuid.String()
+
+	got := uuid.AppendString([]byte("id="))
+	if string(got) != "id="+want {
+		t.Errorf("AppendString() = %q, want %q", got, "id="+want)
+	}
+
+	buf := make([]byte, 0, 36)
+	if allocs := testing.AllocsPerRun(100, func() { buf = uuid.AppendString(buf[:0]) }); allocs != 0 {
+		t.Errorf("AppendString() allocates %v times with enough capacity", allocs)
+	}
+}
+
+// generatedVersions returns one UUID of every version the library generates.
+func generatedVersions(t *testing.T) map[VersionType]*UUID {
+	t.Helper()
+
+	generators := map[VersionType]func() (*UUID, error){
+		VersionTimeBased:     NewV1,
+		VersionDCESecurity:   func() (*UUID, error) { return NewV2(0, 1000) },
+		VersionNameBasedMD5:  func() (*UUID, error) { return NewV3(NamespaceDNS, []byte("python.org")) },
+		VersionRandom:        NewV4,
+		VersionNameBasedSHA1: func() (*UUID, error) { return NewV5(NamespaceDNS, []byte("python.org")) },
+		VersionV6:            NewV6,
+		VersionV7:            NewV7,
+		VersionV8:            func() (*UUID, error) { return NewV8([16]byte{1, 2, 3}) },
+	}
+
+	uuids := make(map[VersionType]*UUID, len(generators))
+	for version, generate := range generators {
+		uuid, err := generate()
+		if err != nil {
+			t.Fatalf("generating version %d: %v", version, err)
+		}
+		uuids[version] = uuid
+	}
+	return uuids
+}
+
+func TestVersionName(t *testing.T) {
+	for want, uuid := range generatedVersions(t) {
+		got, err := uuid.VersionName()
+		if err != nil || got != want {
+			t.Errorf("VersionName(%x) = %v, %v; want %v", uuid.Bytes(), got, err, want)
+		}
+	}
+
+	tests := []struct {
+		version VersionType
+		want    string
+	}{
+		{VersionTimeBased, "time-based"},
+		{VersionRandom, "random"},
+		{VersionV7, "Unix time-based"},
+		{VersionType(15), "VersionType(15)"},
+	}
+	for _, tt := range tests {
+		if got := tt.version.String(); got != tt.want {
+			t.Errorf("VersionType(%d).String() = %q, want %q", uint8(tt.version), got, tt.want)
+		}
+	}
+}
+
+// countdownContext reports cancellation once Err has been called more than
+// calls times, simulating a cancel that arrives mid-generation.
+type countdownContext struct {
+	context.Context
+	calls int
+}
+
+func (c *countdownContext) Err() error {
+	if c.calls == 0 {
+		return context.Canceled
+	}
+	c.calls--
+	return nil
+}
+
+func TestNewV4BatchContextCancelled(t *testing.T) {
+	ctx := &countdownContext{Context: context.Background(), calls: 1}
+
+	uuids, err := NewV4BatchContext(ctx, 3*batchChunkSize)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("NewV4BatchContext() error = %v, want context.Canceled", err)
+	}
+	if len(uuids) != batchChunkSize {
+		t.Errorf("NewV4BatchContext() returned %d UUIDs, want the first chunk of %d", len(uuids), batchChunkSize)
+	}
+	for _, uuid := range uuids {
+		if version, _ := uuid.Version(); version != 4 {
+			t.Fatalf("partial result contains version %d, want 4", version)
+		}
+	}
+}
+
+func TestNewV4BatchContext(t *testing.T) {
+	uuids, err := NewV4BatchContext(context.Background(), 10)
+	if err != nil || len(uuids) != 10 {
+		t.Errorf("NewV4BatchContext(10) = %d UUIDs, %v", len(uuids), err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if uuids, err := NewV4BatchContext(ctx, 10); !errors.Is(err, context.Canceled) || len(uuids) != 0 {
+		t.Errorf("NewV4BatchContext(cancelled) = %d UUIDs, %v", len(uuids), err)
+	}
+	if _, err := NewV4BatchContext(context.Background(), -1); errorCode(err) != 2 {
+		t.Errorf("NewV4BatchContext(-1) error = %v, want code 2", err)
+	}
+}
+
+func TestNewV6(t *testing.T) {
+	before := time.Now().Truncate(time.Microsecond)
+	var previous *UUID
+	for i := 0; i < 100; i++ {
+		uuid, err := NewV6()
+		if err != nil {
+			t.Fatalf("NewV6() error = %v", err)
+		}
+		version, _ := uuid.Version()
+		variant, _ := uuid.Variant()
+		if version != 6 || variant != 2 {
+			t.Errorf("NewV6() has version %d, variant %d; want 6, 2", version, variant)
+		}
+
+		ts, err := uuid.Timestamp()
+		if err != nil || ts.Before(before) || ts.After(time.Now()) {
+			t.Errorf("NewV6().Timestamp() = %v, %v; want about %v", ts, err, before)
+		}
+		if previous != nil {
+			prev, _ := previous.Timestamp()
+			if ts.Before(prev) {
+				t.Errorf("timestamp went backwards: %v after %v", ts, prev)
+			}
+		}
+		previous = uuid
+	}
+}
+
+func TestV1V6RoundTrip(t *testing.T) {
+	// The RFC 9562 v1 and v6 test vectors encode the same instant.
+	v1 := mustParse(t, "c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v6 := mustParse(t, "1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+	if got, err := v1.ToV6(); err != nil || *got != *v6 {
+		t.Errorf("ToV6() = %v, %v; want %x", got, err, v6.Bytes())
+	}
+	if got, err := v6.ToV1(); err != nil || *got != *v1 {
+		t.Errorf("ToV1() = %v, %v; want %x", got, err, v1.Bytes())
+	}
+
+	generated, err := NewV1()
+	if err != nil {
+		t.Fatalf("NewV1() error = %v", err)
+	}
+	converted, err := generated.ToV6()
+	if err != nil {
+		t.Fatalf("ToV6() error = %v", err)
+	}
+	back, err := converted.ToV1()
+	if err != nil || *back != *generated {
+		t.Fatalf("ToV1(ToV6(%x)) = %v, %v", generated.Bytes(), back, err)
+	}
+	t1, _ := generated.Timestamp()
+	t6, err := converted.Timestamp()
+	if err != nil || !t1.Equal(t6) {
+		t.Errorf("v6 timestamp = %v, %v; want %v", t6, err, t1)
+	}
+
+	if _, err := mustNewV4(t).ToV6(); errorCode(err) != 2 {
+		t.Errorf("ToV6(v4) error = %v, want code 2", err)
+	}
+	if _, err := generated.ToV1(); errorCode(err) != 2 {
+		t.Errorf("ToV1(v1) error = %v, want code 2", err)
+	}
+}
+
+func TestBufferedGenerator(t *testing.T) {
+	for _, size := range []int{0, 1, 8} {
+		g := NewBufferedGenerator(size)
+
+		var wg sync.WaitGroup
+		results := make(chan UUID, 100)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 25; j++ {
+					uuid, err := g.Next()
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					results <- *uuid
+				}
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		seen := make(map[UUID]bool)
+		for uuid := range results {
+			if version, _ := uuid.Version(); seen[uuid] || version != 4 {
+				t.Errorf("NewBufferedGenerator(%d).Next() returned %x twice or with version %d", size, uuid.Bytes(), version)
+			}
+			seen[uuid] = true
+		}
+		if len(seen) != 100 {
+			t.Errorf("NewBufferedGenerator(%d) gave %d distinct UUIDs, want 100", size, len(seen))
+		}
+	}
+}
+
+func TestErrorsIs(t *testing.T) {
+	sentinels := []error{ErrEntropyFailure, ErrInvalidParameter, ErrBufferTooSmall, ErrInvalidFormat, ErrUnknown}
+	tests := []struct {
+		code int32
+		want error
+	}{
+		{1, ErrEntropyFailure},
+		{2, ErrInvalidParameter},
+		{3, ErrBufferTooSmall},
+		{4, ErrInvalidFormat},
+		{42, ErrUnknown},
+		{99, ErrUnknown},
+	}
+	for _, tt := range tests {
+		err := error(UUIDError{Code: tt.code, Message: getErrorMessage(tt.code)})
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+				t.Errorf("errors.Is(code %d, %v) = %v", tt.code, sentinel, got)
+			}
+		}
+	}
+
+	_, err := Parse("bogus")
+	var uuidErr UUIDError
+	if !errors.Is(err, ErrInvalidFormat) || !errors.As(err, &uuidErr) || uuidErr.Code != 4 {
+		t.Errorf("Parse(bogus) error = %#v, want a code 4 UUIDError", err)
+	}
+}
+
+func TestStringMatchesFFI(t *testing.T) {
+	uuids, err := NewV4Batch(100)
+	if err != nil {
+		t.Fatalf("NewV4Batch() error = %v", err)
+	}
+
+	for _, uuid := range append(uuids, Nil(), Max()) {
+		var stringer fmt.Stringer = uuid
+		checked, err := uuid.StringChecked()
+		if err != nil || stringer.String() != checked {
+			t.Errorf("String() = %s, StringChecked() = %s, %v", stringer, checked, err)
+		}
+	}
+}
+
+func TestFormatVerbs(t *testing.T) {
+	uuid := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"%s", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"%x", "6ba7b8109dad11d180b400c04fd430c8"},
+		{"%X", "6BA7B8109DAD11D180B400C04FD430C8"},
+		{"%q", `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`},
+		{"%40v", "    6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"%-40v|", "6ba7b810-9dad-11d1-80b4-00c04fd430c8    |"},
+		{"%34x", "  6ba7b8109dad11d180b400c04fd430c8"},
+		{"%d", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, uuid); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestBytesLE(t *testing.T) {
+	uuid := mustParse(t, "00112233-4455-6677-8899-aabbccddeeff")
+	want := [16]byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+
+	if got := uuid.BytesLE(); got != want {
+		t.Errorf("BytesLE() = %x, want %x", got, want)
+	}
+	if got := FromBytesLE(want); *got != *uuid {
+		t.Errorf("FromBytesLE() = %s, want %s", got, uuid)
+	}
+}
+
+func TestIsRFC4122(t *testing.T) {
+	tests := []struct {
+		uuid *UUID
+		want bool
+	}{
+		{mustNewV4(t), true},
+		{NamespaceDNS, true},
+		{mustParse(t, "6ba7b810-9dad-41d1-00b4-00c04fd430c8"), false},
+		{mustParse(t, "6ba7b810-9dad-41d1-c0b4-00c04fd430c8"), false},
+		{mustParse(t, "6ba7b810-9dad-41d1-e0b4-00c04fd430c8"), false},
+		{Nil(), false},
+	}
+	for _, tt := range tests {
+		if got := tt.uuid.IsRFC4122(); got != tt.want {
+			t.Errorf("IsRFC4122(%s) = %v, want %v", tt.uuid, got, tt.want)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	uuid := mustNewV4(t)
+
+	clone := uuid.Clone()
+	if *clone != *uuid || clone == uuid {
+		t.Fatalf("Clone() = %p, want an equal copy of %p", clone, uuid)
+	}
+
+	clone.bytes[0] ^= 0xff
+	if *clone == *uuid {
+		t.Error("changing the clone changed the original")
+	}
+}
+
+func TestNewV7At(t *testing.T) {
+	at := time.UnixMilli(1700000000123)
+	uuid, err := NewV7At(at.Add(456 * time.Microsecond))
+	if err != nil {
+		t.Fatalf("NewV7At() error = %v", err)
+	}
+	if version, _ := uuid.Version(); version != 7 || !uuid.IsRFC4122() {
+		t.Errorf("NewV7At() = %s, want an RFC 4122 v7", uuid)
+	}
+	if got, err := uuid.Timestamp(); err != nil || !got.Equal(at) {
+		t.Errorf("Timestamp() = %v, %v; want %v", got, err, at)
+	}
+
+	other, _ := NewV7At(at)
+	if *other == *uuid || unixMillis(other) != unixMillis(uuid) {
+		t.Errorf("NewV7At() twice = %s, %s; want the same timestamp with different random bits", uuid, other)
+	}
+
+	for _, bad := range []time.Time{time.UnixMilli(-1), time.UnixMilli(1 << 48)} {
+		if _, err := NewV7At(bad); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("NewV7At(%v) error = %v, want ErrInvalidParameter", bad, err)
+		}
+	}
+}
+
+func TestStrings(t *testing.T) {
+	uuids, err := NewV4Batch(5)
+	if err != nil {
+		t.Fatalf("NewV4Batch() error = %v", err)
+	}
+
+	strs, err := Strings(uuids)
+	if err != nil {
+		t.Fatalf("Strings() error = %v", err)
+	}
+	for i, s := range strs {
+		if s != uuids[i].String() {
+			t.Errorf("Strings()[%d] = %s, want %s", i, s, uuids[i])
+		}
+	}
+
+	uuids[3] = nil
+	if _, err := Strings(uuids); !errors.Is(err, ErrInvalidParameter) || !strings.Contains(err.Error(), "index 3") {
+		t.Errorf("Strings(with nil) error = %v, want one naming index 3", err)
+	}
+}
+
+func TestWipe(t *testing.T) {
+	uuid := mustNewV4(t)
+	clone := uuid.Clone()
+
+	clone.Wipe()
+	if !clone.IsNil() {
+		t.Errorf("Wipe() left %s", clone)
+	}
+	if uuid.IsNil() {
+		t.Error("wiping the clone changed the original")
+	}
+}
+
+func TestVersionFastMatchesVersion(t *testing.T) {
+	uuids := []*UUID{Nil(), Max()}
+	for _, uuid := range generatedVersions(t) {
+		uuids = append(uuids, uuid)
+	}
+
+	for _, uuid := range uuids {
+		version, err := uuid.Version()
+		if err != nil || uuid.VersionFast() != version {
+			t.Errorf("VersionFast(%s) = %d, Version() = %d, %v", uuid, uuid.VersionFast(), version, err)
+		}
+	}
+}
+
+func TestRawRandom(t *testing.T) {
+	seen := make(map[[16]byte]bool)
+	versions := make(map[byte]bool)
+	variants := make(map[byte]bool)
+	for i := 0; i < 64; i++ {
+		raw, err := RawRandom()
+		if err != nil {
+			t.Fatalf("RawRandom() error = %v", err)
+		}
+		if seen[raw] {
+			t.Fatalf("RawRandom() repeated %x", raw)
+		}
+		seen[raw] = true
+		versions[raw[6]>>4] = true
+		variants[raw[8]>>6] = true
+	}
+
+	// Stamped bits would pin both fields to a single value.
+	if len(versions) == 1 || len(variants) == 1 {
+		t.Errorf("RawRandom() produced %d version nibbles and %d variant values, want unstamped bytes", len(versions), len(variants))
+	}
+}
+
+func TestCompareTimeThenBytes(t *testing.T) {
+	// The v1 is from 2022 and the v7 from 2023, the reverse of byte order.
+	v1 := mustParse(t, "c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v7, err := NewV7At(time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewV7At() error = %v", err)
+	}
+	sameTime, _ := NewV7At(time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		u, other *UUID
+		want     int
+	}{
+		{"older v1 first", v1, v7, -1},
+		{"newer v7 last", v7, v1, 1},
+		{"equal", v7, v7.Clone(), 0},
+		{"same time ties by bytes", v7, sameTime, v7.Compare(sameTime)},
+	}
+	for _, tt := range tests {
+		if got := tt.u.CompareTimeThenBytes(tt.other); got != tt.want {
+			t.Errorf("CompareTimeThenBytes(%s) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	valid := []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+	}
+
+	uuids, err := ParseAll(valid)
+	if err != nil {
+		t.Fatalf("ParseAll(valid) error = %v", err)
+	}
+	for i, uuid := range uuids {
+		if uuid.String() != valid[i] {
+			t.Errorf("ParseAll(valid)[%d] = %s, want %s", i, uuid, valid[i])
+		}
+	}
+
+	_, err = ParseAll([]string{valid[0], "bogus", "also-bogus"})
+	if !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("ParseAll(invalid) error = %v, want ErrInvalidFormat", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "index 1") || !strings.Contains(msg, `"bogus"`) {
+		t.Errorf("ParseAll(invalid) error = %q, want it to name index 1 and the value", msg)
+	}
+}
+
+func TestParseAllCollect(t *testing.T) {
+	uuids, err := ParseAllCollect([]string{"bogus", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "x"})
+	if err == nil {
+		t.Fatal("ParseAllCollect() error = nil, want the joined failures")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "index 0") || !strings.Contains(msg, "index 2") {
+		t.Errorf("ParseAllCollect() error = %q, want both failing indexes", msg)
+	}
+	if uuids[0] != nil || uuids[1] == nil || uuids[2] != nil {
+		t.Errorf("ParseAllCollect() = %v, want only index 1 set", uuids)
+	}
+}
+
+func TestReseed(t *testing.T) {
+	g := NewSeededGenerator(7)
+
+	generate := func() []UUID {
+		uuids := make([]UUID, 10)
+		for i := range uuids {
+			uuid, err := g.NewV4()
+			if err != nil {
+				t.Fatalf("NewV4() error = %v", err)
+			}
+			uuids[i] = *uuid
+		}
+		return uuids
+	}
+
+	first := generate()
+	g.Reseed(7)
+	second := generate()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("after Reseed, UUID %d = %s, want %s", i, &second[i], &first[i])
+		}
+	}
+
+	fresh, _ := NewSeededGenerator(8).NewV4()
+	g.Reseed(8)
+	if reseeded, _ := g.NewV4(); *reseeded != *fresh {
+		t.Errorf("Reseed(8) gave %s, want the sequence of a new seed-8 generator %s", reseeded, fresh)
+	}
+
+	// Generators backed by other sources ignore Reseed.
+	NewGenerator(nil).Reseed(1)
+}
+
+func TestNewV5String(t *testing.T) {
+	for _, name := range []string{"python.org", "", "ünïcödé"} {
+		got, err := NewV5String(NamespaceDNS, name)
+		if err != nil {
+			t.Fatalf("NewV5String(%q) error = %v", name, err)
+		}
+		want, _ := NewV5(NamespaceDNS, []byte(name))
+		if *got != *want {
+			t.Errorf("NewV5String(%q) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestStringUpper(t *testing.T) {
+	uuid := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	got, err := uuid.StringUpper()
+	if err != nil || got != "6BA7B810-9DAD-11D1-80B4-00C04FD430C8" {
+		t.Errorf("StringUpper() = %q, %v", got, err)
+	}
+	if parsed := mustParse(t, got); *parsed != *uuid {
+		t.Errorf("Parse(StringUpper()) = %s, want %s", parsed, uuid)
+	}
+}
+
+func TestClockSequence(t *testing.T) {
+	for _, s := range []string{"c232ab00-9414-11ec-b3c8-9f6bdeced846", "1ec9414c-232a-6b00-b3c8-9f6bdeced846"} {
+		if seq, err := mustParse(t, s).ClockSequence(); err != nil || seq != 0x33c8 {
+			t.Errorf("ClockSequence(%s) = %#x, %v; want 0x33c8", s, seq, err)
+		}
+	}
+
+	for _, generate := range []func() (*UUID, error){NewV1, NewV6} {
+		uuid, err := generate()
+		if err != nil {
+			t.Fatalf("generate error = %v", err)
+		}
+		if seq, err := uuid.ClockSequence(); err != nil || seq > 0x3fff {
+			t.Errorf("ClockSequence(%s) = %d, %v; want a 14-bit value", uuid, seq, err)
+		}
+	}
+
+	if _, err := mustNewV4(t).ClockSequence(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("ClockSequence(v4) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestNode(t *testing.T) {
+	want := [6]byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}
+	for _, s := range []string{"c232ab00-9414-11ec-b3c8-9f6bdeced846", "1ec9414c-232a-6b00-b3c8-9f6bdeced846"} {
+		if node, err := mustParse(t, s).Node(); err != nil || node != want {
+			t.Errorf("Node(%s) = %x, %v; want %x", s, node, err, want)
+		}
+	}
+
+	node := [6]byte{0x02, 0x00, 0x5e, 0x10, 0x00, 0x01}
+	v1, err := NewV1WithNode(node)
+	if err != nil {
+		t.Fatalf("NewV1WithNode() error = %v", err)
+	}
+	if got, err := v1.Node(); err != nil || got != node {
+		t.Errorf("Node() = %x, %v; want %x", got, err, node)
+	}
+
+	if _, err := mustNewV4(t).Node(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Node(v4) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestNewFromSHA256(t *testing.T) {
+	// SHA-256 of the empty input starts e3b0c44298fc1c149afbf4c8996fb924.
+	if got := NewFromSHA256(nil).String(); got != "e3b0c442-98fc-8c14-9afb-f4c8996fb924" {
+		t.Errorf("NewFromSHA256(nil) = %s", got)
+	}
+
+	a := NewFromSHA256([]byte("content"))
+	b := NewFromSHA256([]byte("content"))
+	c := NewFromSHA256([]byte("other content"))
+	if *a != *b || *a == *c {
+		t.Errorf("NewFromSHA256() = %s, %s, %s; want only the first two equal", a, b, c)
+	}
+	if a.VersionFast() != 8 || !a.IsRFC4122() {
+		t.Errorf("NewFromSHA256() = %s, want an RFC 4122 v8", a)
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	type record struct {
+		XMLName xml.Name `xml:"record"`
+		Owner   UUID     `xml:"owner,attr"`
+		ID      UUID     `xml:"id"`
+	}
+
+	in := record{Owner: *mustNewV4(t), ID: *mustNewV4(t)}
+	data, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatalf("xml.Marshal() error = %v", err)
+	}
+	want := fmt.Sprintf(`<record owner="%s"><id>%s</id></record>`, &in.Owner, &in.ID)
+	if string(data) != want {
+		t.Errorf("xml.Marshal() = %s, want %s", data, want)
+	}
+
+	var out record
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	if out.Owner != in.Owner || out.ID != in.ID {
+		t.Errorf("xml.Unmarshal() = %+v, want %+v", out, in)
+	}
+}
+
+func TestXMLRejectsInvalid(t *testing.T) {
+	var out struct {
+		ID UUID `xml:"id,attr"`
+	}
+	if err := xml.Unmarshal([]byte(`<record id="bogus"></record>`), &out); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("xml.Unmarshal(bogus attr) error = %v, want ErrInvalidFormat", err)
+	}
+
+	var uuid UUID
+	if err := xml.Unmarshal([]byte(`<id> 6ba7b810-9dad-11d1-80b4-00c04fd430c8 </id>`), &uuid); err != nil || uuid != *NamespaceDNS {
+		t.Errorf("xml.Unmarshal(padded element) = %s, %v; want %s", &uuid, err, NamespaceDNS)
+	}
+}
+
+func TestFromAny(t *testing.T) {
+	want := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	raw := want.Bytes()
+
+	for _, v := range []interface{}{want.String(), raw, raw[:], []byte(want.String())} {
+		got, err := FromAny(v)
+		if err != nil || *got != *want {
+			t.Errorf("FromAny(%T) = %v, %v; want %s", v, got, err, want)
+		}
+	}
+
+	_, err := FromAny(3.14)
+	if !errors.Is(err, ErrInvalidParameter) || !strings.Contains(err.Error(), "float64") {
+		t.Errorf("FromAny(float64) error = %v, want one naming the type", err)
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Errorf("SelfTest() error = %v", err)
+	}
+}
+
+func TestNewV4Retry(t *testing.T) {
+	g := NewGenerator(&failingSource{failures: 2})
+	uuid, err := g.NewV4Retry(3, time.Millisecond)
+	if err != nil || uuid.VersionFast() != 4 {
+		t.Errorf("NewV4Retry(3) = %v, %v; want a v4 after two failures", uuid, err)
+	}
+
+	g = NewGenerator(&failingSource{failures: 2})
+	if _, err := g.NewV4Retry(2, time.Millisecond); !errors.Is(err, ErrEntropyFailure) {
+		t.Errorf("NewV4Retry(2) error = %v, want ErrEntropyFailure", err)
+	}
+
+	if _, err := NewV4Retry(0, 0); err != nil {
+		t.Errorf("NewV4Retry(0) error = %v, want one successful attempt", err)
+	}
+}
+
+func TestRetryEntropyStopsOnOtherErrors(t *testing.T) {
+	calls := 0
+	_, err := retryEntropy(5, 0, func() (*UUID, error) {
+		calls++
+		return nil, UUIDError{Code: 2, Message: getErrorMessage(2)}
+	})
+	if !errors.Is(err, ErrInvalidParameter) || calls != 1 {
+		t.Errorf("retryEntropy() = %v after %d calls, want ErrInvalidParameter after 1", err, calls)
+	}
+}
+
+func TestShortID(t *testing.T) {
+	uuids, err := NewV4Batch(50)
+	if err != nil {
+		t.Fatalf("NewV4Batch() error = %v", err)
+	}
+
+	for _, uuid := range uuids {
+		id := uuid.ShortID()
+		decoded, err := ParseShortID(id)
+		if err != nil || *decoded != *uuid {
+			t.Fatalf("ParseShortID(%q) = %v, %v; want %s", id, decoded, err, uuid)
+		}
+		if decoded, err := ParseShortID(strings.ToLower(id)); err != nil || *decoded != *uuid {
+			t.Errorf("ParseShortID(lowercase %q) = %v, %v", id, decoded, err)
+		}
+
+		corrupted := []byte(id)
+		corrupted[10] = crockfordAlphabet[(strings.IndexByte(crockfordAlphabet, id[10])+1)%32]
+		if _, err := ParseShortID(string(corrupted)); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("ParseShortID(%q) error = %v, want a checksum mismatch", corrupted, err)
+		}
+	}
+
+	tests := []struct {
+		uuid *UUID
+		want string
+	}{
+		{Nil(), "000000000000000000000000000"},
+		{Max(), "7ZZZZZZZZZZZZZZZZZZZZZZZZZ*"},
+	}
+	for _, tt := range tests {
+		if got := tt.uuid.ShortID(); got != tt.want {
+			t.Errorf("ShortID(%s) = %q, want %q", tt.uuid, got, tt.want)
+		}
+	}
+}
+
+func TestUint64PairRoundTrip(t *testing.T) {
+	uuids, err := NewV4Batch(100)
+	if err != nil {
+		t.Fatalf("NewV4Batch() error = %v", err)
+	}
+
+	for _, uuid := range append(uuids, Nil(), Max()) {
+		hi, lo := uuid.Uint64Pair()
+		if got := FromUint64Pair(hi, lo); *got != *uuid {
+			t.Fatalf("FromUint64Pair(Uint64Pair(%s)) = %s", uuid, got)
+		}
+	}
+
+	hi, lo := mustParse(t, "00112233-4455-6677-8899-aabbccddeeff").Uint64Pair()
+	if hi != 0x0011223344556677 || lo != 0x8899aabbccddeeff {
+		t.Errorf("Uint64Pair() = %#x, %#x", hi, lo)
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	if Nil().BigInt().Sign() != 0 {
+		t.Errorf("Nil().BigInt() = %s, want 0", Nil().BigInt())
+	}
+
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	if Max().BigInt().Cmp(max) != 0 {
+		t.Errorf("Max().BigInt() = %s, want 2^128-1", Max().BigInt())
+	}
+
+	for _, uuid := range []*UUID{Nil(), Max(), mustNewV4(t)} {
+		got, err := FromBigInt(uuid.BigInt())
+		if err != nil || *got != *uuid {
+			t.Errorf("FromBigInt(BigInt(%s)) = %v, %v", uuid, got, err)
+		}
+	}
+
+	for _, n := range []*big.Int{new(big.Int).Add(max, big.NewInt(1)), big.NewInt(-1)} {
+		if _, err := FromBigInt(n); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("FromBigInt(%s) error = %v, want ErrInvalidParameter", n, err)
+		}
+	}
+}
+
+func TestUUIDsSort(t *testing.T) {
+	uuids, err := NewV4Batch(50)
+	if err != nil {
+		t.Fatalf("NewV4Batch() error = %v", err)
+	}
+
+	sort.Sort(UUIDs(uuids))
+	for i := 1; i < len(uuids); i++ {
+		if uuids[i-1].Compare(uuids[i]) >= 0 {
+			t.Fatalf("UUIDs not ascending at %d: %s >= %s", i, uuids[i-1], uuids[i])
+		}
+	}
+}
+
+func TestTimeBucket(t *testing.T) {
+	hour := time.Hour.Milliseconds()
+
+	tests := []struct {
+		unixMS int64
+		want   int64
+	}{
+		{0, 0},
+		{hour - 1, 0},
+		{hour, 1},
+		{5*hour + 123, 5},
+	}
+	for _, tt := range tests {
+		uuid, err := NewV7At(time.UnixMilli(tt.unixMS))
+		if err != nil {
+			t.Fatalf("NewV7At(%d) error = %v", tt.unixMS, err)
+		}
+
+		got, err := uuid.TimeBucket(time.Hour)
+		if err != nil || got != tt.want {
+			t.Errorf("TimeBucket() at %dms = %d, %v; want %d", tt.unixMS, got, err, tt.want)
+		}
+	}
+}
+
+func TestDeterministicSequence(t *testing.T) {
+	a := DeterministicSequence(1, 20)
+	b := DeterministicSequence(1, 20)
+	c := DeterministicSequence(2, 20)
+
+	if len(a) != 20 {
+		t.Fatalf("DeterministicSequence() returned %d UUIDs, want 20", len(a))
+	}
+	for i := range a {
+		if *a[i] != *b[i] {
+			t.Errorf("same seed differs at %d: %s vs %s", i, a[i], b[i])
+		}
+		if *a[i] == *c[i] {
+			t.Errorf("different seeds agree at %d: %s", i, a[i])
+		}
+		if a[i].VersionFast() != 4 {
+			t.Errorf("DeterministicSequence()[%d] = %s, want version 4", i, a[i])
+		}
+	}
+}
+
+func TestWithVersion(t *testing.T) {
+	v4 := mustNewV4(t)
+	v8, err := v4.WithVersion(8)
+	if err != nil {
+		t.Fatalf("WithVersion(8) error = %v", err)
+	}
+
+	a, b := v4.Bytes(), v8.Bytes()
+	for i := range a {
+		if i == 6 {
+			if a[i]&0x0f != b[i]&0x0f || b[i]>>4 != 8 {
+				t.Errorf("byte 6 = %#x, want version 8 with the low nibble of %#x", b[i], a[i])
+			}
+		} else if a[i] != b[i] {
+			t.Errorf("byte %d changed from %#x to %#x", i, a[i], b[i])
+		}
+	}
+
+	for _, v := range []uint8{0, 9} {
+		if _, err := v4.WithVersion(v); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("WithVersion(%d) error = %v, want ErrInvalidParameter", v, err)
+		}
+	}
+}
+
+func TestNewV4WithPrefix(t *testing.T) {
+	prefix := []byte{0xde, 0xad, 0xbe, 0xef, 0x00, 0x01}
+	uuid, err := NewV4WithPrefix(prefix)
+	if err != nil {
+		t.Fatalf("NewV4WithPrefix() error = %v", err)
+	}
+
+	raw := uuid.Bytes()
+	if !bytes.Equal(raw[:len(prefix)], prefix) || uuid.VersionFast() != 4 || !uuid.IsRFC4122() {
+		t.Errorf("NewV4WithPrefix() = %s, want an RFC 4122 v4 starting with %x", uuid, prefix)
+	}
+
+	if _, err := NewV4WithPrefix(make([]byte, 17)); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewV4WithPrefix(17 bytes) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestRandomInRange(t *testing.T) {
+	low := mustParse(t, "00000000-0000-4000-8000-000000000000")
+	high := mustFromBigInt(t, new(big.Int).Add(low.BigInt(), big.NewInt(99)))
+
+	seen := make(map[UUID]bool)
+	for i := 0; i < 2000; i++ {
+		uuid, err := RandomInRange(low, high)
+		if err != nil {
+			t.Fatalf("RandomInRange() error = %v", err)
+		}
+		if uuid.Compare(low) < 0 || uuid.Compare(high) > 0 {
+			t.Fatalf("RandomInRange() = %s, outside [%s, %s]", uuid, low, high)
+		}
+		seen[*uuid] = true
+	}
+	// 2000 draws from 100 values miss any given value with probability
+	// about 2e-9, so every value should appear.
+	if len(seen) != 100 {
+		t.Errorf("RandomInRange() hit %d of 100 values", len(seen))
+	}
+
+	if uuid, err := RandomInRange(Nil(), Max()); err != nil || uuid == nil {
+		t.Errorf("RandomInRange(full range) = %v, %v", uuid, err)
+	}
+	if _, err := RandomInRange(high, low); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("RandomInRange(high, low) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+// mustFromBigInt is FromBigInt for values known to be in range.
+func mustFromBigInt(t *testing.T, n *big.Int) *UUID {
+	t.Helper()
+
+	uuid, err := FromBigInt(n)
+	if err != nil {
+		t.Fatalf("FromBigInt(%s) error = %v", n, err)
+	}
+	return uuid
+}
+
+func TestSameTimestamp(t *testing.T) {
+	at := time.UnixMilli(1700000000000)
+	a, _ := NewV7At(at)
+	b, _ := NewV7At(at)
+	c, _ := NewV7At(at.Add(time.Millisecond))
+
+	if same, err := a.SameTimestamp(b); !same || err != nil {
+		t.Errorf("SameTimestamp(same time) = %v, %v; want true", same, err)
+	}
+	if same, err := a.SameTimestamp(c); same || err != nil {
+		t.Errorf("SameTimestamp(next ms) = %v, %v; want false", same, err)
+	}
+	if _, err := a.SameTimestamp(mustNewV4(t)); err == nil {
+		t.Error("SameTimestamp(v4) error = nil")
+	}
+}
+
+func TestDebug(t *testing.T) {
+	uuid, err := NewV7()
+	if err != nil {
+		t.Fatalf("NewV7() error = %v", err)
+	}
+
+	dump := uuid.Debug()
+	for _, want := range []string{"UUID:      " + uuid.String(), "Version:   7 (Unix time-based)", "Timestamp: "} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("Debug() = %q, want it to contain %q", dump, want)
+		}
+	}
+}
+
+func TestGenerateV4Into(t *testing.T) {
+	var uuid UUID
+	if err := GenerateV4Into(&uuid); err != nil || uuid.VersionFast() != 4 || !uuid.IsRFC4122() {
+		t.Errorf("GenerateV4Into() = %s, %v; want an RFC 4122 v4", &uuid, err)
+	}
+	if err := GenerateV4Into(nil); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("GenerateV4Into(nil) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestPopCount(t *testing.T) {
+	if Nil().PopCount() != 0 || Max().PopCount() != 128 {
+		t.Errorf("PopCount() of Nil and Max = %d, %d; want 0, 128", Nil().PopCount(), Max().PopCount())
+	}
+
+	uuids, err := NewV4Batch(2000)
+	if err != nil {
+		t.Fatalf("NewV4Batch() error = %v", err)
+	}
+	total := 0
+	for _, uuid := range uuids {
+		total += uuid.PopCount()
+	}
+	// The expected mean is 63 with a standard error of about 0.12.
+	if mean := float64(total) / float64(len(uuids)); mean < 61 || mean > 65 {
+		t.Errorf("mean PopCount() = %.2f, want about 63", mean)
+	}
+}
+
+func TestNameBasedNamespaces(t *testing.T) {
+	custom := mustNewV4(t)
+	if uuid, err := NewV5(custom, []byte("name")); err != nil || uuid.VersionFast() != 5 {
+		t.Errorf("NewV5(custom namespace) = %v, %v", uuid, err)
+	}
+	if uuid, err := NewV3(custom, []byte("name")); err != nil || uuid.VersionFast() != 3 {
+		t.Errorf("NewV3(custom namespace) = %v, %v", uuid, err)
+	}
+
+	if _, err := NewV5(nil, []byte("name")); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewV5(nil) error = %v, want ErrInvalidParameter", err)
+	}
+	if _, err := NewV3(nil, []byte("name")); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewV3(nil) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestSetDefaultNode(t *testing.T) {
+	node := [6]byte{0x02, 0xaa, 0xbb, 0xcc, 0xdd, 0xee}
+	SetDefaultNode(node)
+	t.Cleanup(resetDefaultNode)
+
+	uuid, err := NewV1()
+	if err != nil {
+		t.Fatalf("NewV1() error = %v", err)
+	}
+	if got, err := uuid.Node(); err != nil || got != node {
+		t.Errorf("Node() = %x, %v; want %x", got, err, node)
+	}
+}
+
+func TestV4FromRandom(t *testing.T) {
+	uuid, err := V4FromRandom(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16)))
+	if err != nil || uuid.String() != "ffffffff-ffff-4fff-bfff-ffffffffffff" {
+		t.Errorf("V4FromRandom(0xff...) = %v, %v", uuid, err)
+	}
+
+	if _, err := V4FromRandom(bytes.NewReader(make([]byte, 8))); !errors.Is(err, ErrEntropyFailure) {
+		t.Errorf("V4FromRandom(short) error = %v, want ErrEntropyFailure", err)
+	}
+}
+
+func TestComplement(t *testing.T) {
+	uuid := mustNewV4(t)
+	if got := uuid.Complement().Complement(); *got != *uuid {
+		t.Errorf("Complement().Complement() = %s, want %s", got, uuid)
+	}
+	if got := Nil().Complement(); !got.IsMax() {
+		t.Errorf("Nil().Complement() = %s, want Max", got)
+	}
+}
+
+func TestNewV2(t *testing.T) {
+	uuid, err := NewV2(1, 4242)
+	if err != nil {
+		t.Fatalf("NewV2() error = %v", err)
+	}
+
+	domain, err := uuid.Domain()
+	if err != nil || domain != 1 {
+		t.Errorf("Domain() = %d, %v; want 1", domain, err)
+	}
+	id, err := uuid.ID()
+	if err != nil || id != 4242 {
+		t.Errorf("ID() = %d, %v; want 4242", id, err)
+	}
+	if _, err := mustNewV4(t).ID(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("ID(v4) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestRegisterErrorMessage(t *testing.T) {
+	const code = 1001
+	if got := getErrorMessage(1002); got != "Undefined error code" {
+		t.Errorf("getErrorMessage(1002) = %q, want the undefined message", got)
+	}
+
+	RegisterErrorMessage(code, "quota exceeded")
+	err := UUIDError{Code: code, Message: getErrorMessage(code)}
+	if err.Error() != "UUID error 1001: quota exceeded" {
+		t.Errorf("UUIDError.Error() = %q, want the registered message", err.Error())
+	}
+	if got := getErrorMessage(4); got != "Invalid UUID string format" {
+		t.Errorf("getErrorMessage(4) = %q, want the default", got)
+	}
+}
+
+func TestRerandomizeV7(t *testing.T) {
+	v7, err := NewV7()
+	if err != nil {
+		t.Fatalf("NewV7() error = %v", err)
+	}
+
+	rerandomized, err := v7.RerandomizeV7()
+	if err != nil {
+		t.Fatalf("RerandomizeV7() error = %v", err)
+	}
+	if *rerandomized == *v7 || rerandomized.VersionFast() != 7 || !rerandomized.IsRFC4122() {
+		t.Errorf("RerandomizeV7() = %s, want a different RFC 4122 v7 than %s", rerandomized, v7)
+	}
+	if same, err := v7.SameTimestamp(rerandomized); !same || err != nil {
+		t.Errorf("SameTimestamp() = %v, %v; want the timestamp kept", same, err)
+	}
+	a, b := v7.Bytes(), rerandomized.Bytes()
+	if !bytes.Equal(a[:8], b[:8]) {
+		t.Errorf("RerandomizeV7() changed the timestamp or counter: %x -> %x", a[:8], b[:8])
+	}
+
+	if _, err := mustNewV4(t).RerandomizeV7(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("RerandomizeV7(v4) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestGenerateV4Packed(t *testing.T) {
+	packed, err := GenerateV4Packed(10)
+	if err != nil || len(packed) != 160 {
+		t.Fatalf("GenerateV4Packed(10) = %d bytes, %v", len(packed), err)
+	}
+
+	for i := 0; i < 10; i++ {
+		var raw [16]byte
+		copy(raw[:], packed[i*16:])
+		if uuid := FromBytes(raw); Validate(uuid.String(), 4) != nil {
+			t.Errorf("chunk %d = %s, want a valid v4", i, uuid)
+		}
+	}
+}
+
+func TestEqualIgnoringVersion(t *testing.T) {
+	v4 := mustNewV4(t)
+	v8, err := v4.WithVersion(8)
+	if err != nil {
+		t.Fatalf("WithVersion(8) error = %v", err)
+	}
+
+	if !v4.EqualIgnoringVersion(v8) {
+		t.Errorf("EqualIgnoringVersion(%s, %s) = false", v4, v8)
+	}
+	if equal, _ := v4.Equal(v8); equal {
+		t.Errorf("Equal(%s, %s) = true", v4, v8)
+	}
+	if v4.EqualIgnoringVersion(mustNewV4(t)) {
+		t.Error("EqualIgnoringVersion() = true for unrelated UUIDs")
+	}
+}
+
+func TestIsStandardNamespace(t *testing.T) {
+	tests := []struct {
+		uuid *UUID
+		name string
+		ok   bool
+	}{
+		{NamespaceDNS, "DNS", true},
+		{NamespaceURL, "URL", true},
+		{NamespaceOID, "OID", true},
+		{NamespaceX500, "X500", true},
+		{mustParse(t, "6ba7b813-9dad-11d1-80b4-00c04fd430c8"), "", false},
+	}
+	for _, tt := range tests {
+		name, ok := tt.uuid.IsStandardNamespace()
+		if name != tt.name || ok != tt.ok {
+			t.Errorf("IsStandardNamespace(%s) = %q, %v; want %q, %v", tt.uuid, name, ok, tt.name, tt.ok)
+		}
+	}
+}
+
+func TestParseStream(t *testing.T) {
+	input := "6ba7b810-9dad-11d1-80b4-00c04fd430c8\n" +
+		"\n" +
+		"6ba7b811-9dad-11d1-80b4-00c04fd430c8\r\n"
+
+	uuids, err := ParseStream(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseStream() error = %v", err)
+	}
+	if len(uuids) != 2 {
+		t.Fatalf("ParseStream() returned %d UUIDs, want 2", len(uuids))
+	}
+
+	_, err = ParseStream(strings.NewReader(input + "not-a-uuid\n"))
+	if !errors.Is(err, ErrInvalidFormat) || !strings.Contains(err.Error(), "line 4:") {
+		t.Errorf("ParseStream(malformed) error = %v, want a line 4 ErrInvalidFormat", err)
+	}
+}
+
+func TestGUIDString(t *testing.T) {
+	uuid := mustParse(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	tests := []struct {
+		format byte
+		want   string
+	}{
+		{'D', "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{'N', "6ba7b8109dad11d180b400c04fd430c8"},
+		{'B', "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"},
+		{'P', "(6ba7b810-9dad-11d1-80b4-00c04fd430c8)"},
+		{'n', "6ba7b8109dad11d180b400c04fd430c8"},
+	}
+	for _, tt := range tests {
+		got, err := uuid.GUIDString(tt.format)
+		if err != nil || got != tt.want {
+			t.Errorf("GUIDString(%q) = %q, %v; want %q", tt.format, got, err, tt.want)
+		}
+	}
+
+	if _, err := uuid.GUIDString('X'); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("GUIDString('X') error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestWriteStringArray(t *testing.T) {
+	uuid := mustNewV4(t)
+
+	var dst [36]byte
+	uuid.WriteStringArray(&dst)
+	if string(dst[:]) != uuid.String() {
+		t.Errorf("WriteStringArray() = %q, want %q", dst[:], uuid.String())
+	}
+	if allocs := testing.AllocsPerRun(100, func() { uuid.WriteStringArray(&dst) }); allocs != 0 {
+		t.Errorf("WriteStringArray() allocates %v times", allocs)
+	}
+}
+
+func TestParseWithVersion(t *testing.T) {
+	uuid, version, err := ParseWithVersion("886313e1-3b8a-5372-9b90-0c9aee199e5d")
+	if err != nil || version != 5 || uuid.String() != "886313e1-3b8a-5372-9b90-0c9aee199e5d" {
+		t.Errorf("ParseWithVersion() = %v, %d, %v; want version 5", uuid, version, err)
+	}
+
+	if _, _, err := ParseWithVersion("bogus"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("ParseWithVersion(bogus) error = %v, want ErrInvalidFormat", err)
+	}
+}
+
+// sequenceSource fills every buffer with the next value from a fixed list,
+// repeating the last one once the list runs out.
+type sequenceSource struct {
+	values [][16]byte
+	next   int
+}
+
+func (s *sequenceSource) Fill(buf []byte) error {
+	copy(buf, s.values[s.next][:])
+	if s.next < len(s.values)-1 {
+		s.next++
+	}
+	return nil
+}
+
+func TestUniqueGeneratorSkipsDuplicates(t *testing.T) {
+	dup := [16]byte{1}
+	fresh := [16]byte{2}
+	source := &sequenceSource{values: [][16]byte{dup, fresh}}
+
+	g := NewUniqueGenerator(source)
+	reserved := FromBytes(dup)
+	stampVersion(&reserved.bytes, 4)
+	g.Reserve(reserved)
+
+	uuid, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if uuid.bytes[0] != 2 {
+		t.Errorf("Next() = %s, want the fresh value after the reserved one", uuid)
+	}
+}
+
+func TestEqualString(t *testing.T) {
+	uuid := mustNewV4(t)
+
+	if equal, err := uuid.EqualString(uuid.String()); !equal || err != nil {
+		t.Errorf("EqualString(own string) = %v, %v; want true", equal, err)
+	}
+	if equal, err := uuid.EqualString(NamespaceDNS.String()); equal || err != nil {
+		t.Errorf("EqualString(other) = %v, %v; want false", equal, err)
+	}
+	if _, err := uuid.EqualString("garbage"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("EqualString(garbage) error = %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestChild(t *testing.T) {
+	child, err := NamespaceDNS.Child("a")
+	if err != nil {
+		t.Fatalf("Child() error = %v", err)
+	}
+	want, _ := NewV5String(NamespaceDNS, "a")
+	if *child != *want || child.String() != "4f3f2898-69e3-5a0d-820a-c4e87987dbce" {
+		t.Errorf("Child(a) = %s, want %s", child, want)
+	}
+}
+
+func TestNewV5Batch(t *testing.T) {
+	names := [][]byte{[]byte("python.org"), nil, []byte("example.com"), []byte("a")}
+
+	uuids, err := NewV5Batch(NamespaceURL, names)
+	if err != nil || len(uuids) != len(names) {
+		t.Fatalf("NewV5Batch() = %d UUIDs, %v", len(uuids), err)
+	}
+	for i, name := range names {
+		want, _ := NewV5(NamespaceURL, name)
+		if *uuids[i] != *want {
+			t.Errorf("NewV5Batch()[%d] = %s, want %s", i, uuids[i], want)
+		}
+	}
+
+	if uuids, err := NewV5Batch(NamespaceURL, nil); err != nil || len(uuids) != 0 {
+		t.Errorf("NewV5Batch(no names) = %v, %v", uuids, err)
+	}
+	if _, err := NewV5Batch(nil, names); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewV5Batch(nil namespace) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := NewContext()
+
+	seen := make(map[UUID]bool)
+	for i := 0; i < 600; i++ {
+		uuid, err := ctx.NewV4()
+		if err != nil {
+			t.Fatalf("Context.NewV4() error = %v", err)
+		}
+		if seen[*uuid] || uuid.VersionFast() != 4 || !uuid.IsRFC4122() {
+			t.Fatalf("Context.NewV4() = %s, want a fresh RFC 4122 v4", uuid)
+		}
+		seen[*uuid] = true
+	}
+
+	if err := ctx.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := ctx.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	if _, err := ctx.NewV4(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewV4() after Close error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestUniqueGeneratorGivesUpOnConstantSource(t *testing.T) {
+	g := NewUniqueGenerator(&sequenceSource{values: [][16]byte{{7}}})
+
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("first Next() error = %v", err)
+	}
+	_, err := g.Next()
+	if !errors.Is(err, ErrEntropyFailure) {
+		t.Errorf("second Next() error = %v, want ErrEntropyFailure", err)
+	}
+}
+
+func TestCompareTimeThenBytesOrdersTimestampedFirst(t *testing.T) {
+	v4 := mustParse(t, "00000000-0000-4000-8000-000000000000")
+	v7 := mustParse(t, "ffffffff-ffff-7fff-bfff-ffffffffffff")
+
+	if got := v7.CompareTimeThenBytes(v4); got != -1 {
+		t.Errorf("v7.CompareTimeThenBytes(v4) = %d, want -1", got)
+	}
+	if got := v4.CompareTimeThenBytes(v7); got != 1 {
+		t.Errorf("v4.CompareTimeThenBytes(v7) = %d, want 1", got)
+	}
+	if got := v4.CompareTimeThenBytes(v4); got != 0 {
+		t.Errorf("v4.CompareTimeThenBytes(v4) = %d, want 0", got)
+	}
+}
+
+func TestCompareTimeThenBytesIsTotal(t *testing.T) {
+	a := mustParse(t, "f0000000-0000-1000-8000-000000000000")
+	b := mustParse(t, "80000000-0000-4000-8000-000000000000")
+	c := mustParse(t, "00000001-0001-1000-8000-000000000000")
+
+	uuids := []*UUID{b, c, a}
+	sort.Slice(uuids, func(i, j int) bool {
+		return uuids[i].CompareTimeThenBytes(uuids[j]) < 0
+	})
+
+	want := []*UUID{a, c, b}
+	for i := range want {
+		if uuids[i] != want[i] {
+			t.Fatalf("sorted = %v, want %v", uuids, want)
+		}
+	}
+	for _, x := range want {
+		for _, y := range want {
+			if x.CompareTimeThenBytes(y) != -y.CompareTimeThenBytes(x) {
+				t.Errorf("CompareTimeThenBytes(%s, %s) is not antisymmetric", x, y)
+			}
+		}
+	}
+}
+
+func TestPrefixErrorWrapsOtherErrors(t *testing.T) {
+	err := prefixError(io.ErrUnexpectedEOF, "line 3")
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("prefixError() = %v, want it to wrap the original error", err)
+	}
+	if err.Error() != "line 3: unexpected EOF" {
+		t.Errorf("prefixError().Error() = %q", err.Error())
+	}
+}
+
+func TestTimeBucketBeforeEpoch(t *testing.T) {
+	// One millisecond before 1970, in 100ns ticks since the Gregorian epoch.
+	ticks := uint64(0x01b21dd213814000 - 10000)
+	var b [16]byte
+	binary.BigEndian.PutUint32(b[0:4], uint32(ticks))
+	binary.BigEndian.PutUint16(b[4:6], uint16(ticks>>32))
+	binary.BigEndian.PutUint16(b[6:8], uint16(ticks>>48)&0x0fff)
+	stampVersion(&b, 1)
+
+	got, err := FromBytes(b).TimeBucket(time.Hour)
+	if err != nil || got != -1 {
+		t.Errorf("TimeBucket() just before the epoch = %d, %v; want -1", got, err)
+	}
+}
+
+func BenchmarkNewV4(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewV4(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewV4Batch(b *testing.B) {
+	for _, size := range []int{1, 100, 10000} {
+		b.Run(fmt.Sprintf("batch=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := NewV4Batch(size); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("loop=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					if _, err := NewV4(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	type record struct {
+		ID UUID
+	}
+
+	in := record{ID: *mustNewV4(t)}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil || out != in {
+		t.Errorf("Decode() = %+v, %v; want %+v", out, err, in)
+	}
+
+	var uuid UUID
+	for _, n := range []int{0, 15, 17} {
+		if err := uuid.UnmarshalBinary(make([]byte, n)); errorCode(err) != 2 {
+			t.Errorf("UnmarshalBinary(%d bytes) error = %v, want code 2", n, err)
+		}
+	}
+}
+
+func BenchmarkAppendString(b *testing.B) {
+	uuid := mustNewV4(b)
+	buf := make([]byte, 0, 36)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = uuid.AppendString(buf[:0])
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	uuid := mustNewV4(b)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = uuid.String()
+	}
+}
+
+func BenchmarkBufferedGeneratorNext(b *testing.B) {
+	g := NewBufferedGenerator(256)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.Next(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateV4Into(b *testing.B) {
+	var uuid UUID
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := GenerateV4Into(&uuid); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewV5Batch(b *testing.B) {
+	names := make([][]byte, 1000)
+	for i := range names {
+		names[i] = []byte(fmt.Sprintf("host%d.example.com", i))
+	}
+
+	b.Run("batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := NewV5Batch(NamespaceDNS, names); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, name := range names {
+				if _, err := NewV5(NamespaceDNS, name); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkContextNewV4(b *testing.B) {
+	ctx := NewContext()
+	defer ctx.Close()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ctx.NewV4(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
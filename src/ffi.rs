@@ -37,11 +37,18 @@
 //! }
 //! ```
 
-use crate::{Uuid, UuidError};
+use crate::{FormatStyle, Uuid, UuidContext, UuidError};
+use std::ffi::CString;
 use std::os::raw::{c_char, c_int};
 use std::ptr;
+use std::cell::RefCell;
 use std::slice;
 
+thread_local! {
+    /// Detail message of the most recent failure on this thread
+    static LAST_ERROR: RefCell<String> = RefCell::new(String::new());
+}
+
 /// Error codes returned by FFI functions
 #[repr(C)]
 pub enum UuidFfiError {
@@ -53,10 +60,61 @@ pub enum UuidFfiError {
     InvalidParameter = 2,
     /// Buffer too small for output
     BufferTooSmall = 3,
+    /// Input is not a valid UUID string
+    InvalidFormat = 4,
     /// Unknown error
     UnknownError = 99,
 }
 
+/// Stores the detail of `err` for `uuid_last_error` and returns its error code
+fn record_error(err: UuidError) -> c_int {
+    let (code, detail) = match err {
+        UuidError::EntropyError(msg) => (UuidFfiError::EntropyFailure, msg),
+        UuidError::InvalidFormat(msg) => (UuidFfiError::InvalidFormat, msg),
+    };
+    LAST_ERROR.with(|last| *last.borrow_mut() = detail);
+    code as c_int
+}
+
+/// Copies the detail message of the most recent failure on the calling thread
+///
+/// Error codes only describe the category of a failure; this returns the
+/// underlying cause, e.g. the OS error encountered while reading entropy or
+/// the position of an invalid character in a parsed string.
+/// The message is only meaningful when read on the same thread right after
+/// a call returned a non-zero code.
+///
+/// # Parameters
+/// - `buffer`: Pointer to a buffer where the null-terminated message will be written
+/// - `buffer_size`: Size of the buffer in bytes
+///
+/// # Returns
+/// - `0` (Success) if the message was written (empty if no failure was recorded)
+/// - `2` (InvalidParameter) if buffer is null
+/// - `3` (BufferTooSmall) if the message and null terminator do not fit
+///
+/// # Safety
+/// The caller must ensure that `buffer` points to at least `buffer_size` writable bytes.
+#[no_mangle]
+pub extern "C" fn uuid_last_error(buffer: *mut c_char, buffer_size: usize) -> c_int {
+    if buffer.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    LAST_ERROR.with(|last| {
+        let last = last.borrow();
+        if last.len() + 1 > buffer_size {
+            return UuidFfiError::BufferTooSmall as c_int;
+        }
+
+        unsafe {
+            ptr::copy_nonoverlapping(last.as_ptr() as *const c_char, buffer, last.len());
+            *buffer.add(last.len()) = 0;
+        }
+        UuidFfiError::Success as c_int
+    })
+}
+
 /// Generates a new UUID v4 and writes the bytes to the provided buffer
 ///
 /// # Parameters
@@ -83,11 +141,520 @@ pub extern "C" fn uuid_generate_v4(uuid_bytes: *mut u8) -> c_int {
             }
             UuidFfiError::Success as c_int
         }
-        Err(UuidError::EntropyError(_)) => UuidFfiError::EntropyFailure as c_int,
-        Err(_) => UuidFfiError::UnknownError as c_int,
+        Err(e) => record_error(e),
+    }
+}
+
+/// Generates a new time-based UUID v1 and writes the bytes to the provided buffer
+///
+/// # Parameters
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `1` (EntropyFailure) if random data generation failed
+/// - `2` (InvalidParameter) if uuid_bytes is null
+///
+/// # Safety
+/// The caller must ensure that `uuid_bytes` points to a valid 16-byte buffer.
+#[no_mangle]
+pub extern "C" fn uuid_generate_v1(uuid_bytes: *mut u8) -> c_int {
+    if uuid_bytes.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    match Uuid::new_v1() {
+        Ok(uuid) => {
+            unsafe {
+                let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+                buffer.copy_from_slice(uuid.as_bytes());
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
+    }
+}
+
+/// Generates a new time-based UUID v1 with a caller-supplied node identifier
+///
+/// # Parameters
+/// - `node`: Pointer to the 6-byte node identifier (e.g. a MAC address)
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `1` (EntropyFailure) if random data generation failed
+/// - `2` (InvalidParameter) if any pointer is null
+///
+/// # Safety
+/// The caller must ensure that `node` points to 6 readable bytes and that
+/// `uuid_bytes` points to a valid 16-byte buffer.
+#[no_mangle]
+pub extern "C" fn uuid_generate_v1_with_node(node: *const u8, uuid_bytes: *mut u8) -> c_int {
+    if node.is_null() || uuid_bytes.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    let mut node_array = [0u8; 6];
+    node_array.copy_from_slice(unsafe { slice::from_raw_parts(node, 6) });
+
+    match Uuid::new_v1_with_node(node_array) {
+        Ok(uuid) => {
+            unsafe {
+                let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+                buffer.copy_from_slice(uuid.as_bytes());
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
+    }
+}
+
+/// Generates a new DCE Security UUID v2 and writes the bytes to the provided buffer
+///
+/// # Parameters
+/// - `domain`: Local domain stored in the clock_seq_low byte
+/// - `id`: Local identifier stored in the time_low field
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `1` (EntropyFailure) if random data generation failed
+/// - `2` (InvalidParameter) if uuid_bytes is null
+///
+/// # Safety
+/// The caller must ensure that `uuid_bytes` points to a valid 16-byte buffer.
+#[no_mangle]
+pub extern "C" fn uuid_generate_v2(domain: u8, id: u32, uuid_bytes: *mut u8) -> c_int {
+    if uuid_bytes.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    match Uuid::new_v2(domain, id) {
+        Ok(uuid) => {
+            unsafe {
+                let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+                buffer.copy_from_slice(uuid.as_bytes());
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
+    }
+}
+
+/// Generates a new reordered time-based UUID v6 and writes the bytes to the provided buffer
+///
+/// # Parameters
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `1` (EntropyFailure) if random data generation failed
+/// - `2` (InvalidParameter) if uuid_bytes is null
+///
+/// # Safety
+/// The caller must ensure that `uuid_bytes` points to a valid 16-byte buffer.
+#[no_mangle]
+pub extern "C" fn uuid_generate_v6(uuid_bytes: *mut u8) -> c_int {
+    if uuid_bytes.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    match Uuid::new_v6() {
+        Ok(uuid) => {
+            unsafe {
+                let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+                buffer.copy_from_slice(uuid.as_bytes());
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
+    }
+}
+
+/// Writes 16 bytes of raw CSPRNG output to the provided buffer
+///
+/// Unlike `uuid_generate_v4`, no version or variant bits are set, so the
+/// result is not a valid UUID until the caller stamps its own.
+///
+/// # Parameters
+/// - `out`: Pointer to a 16-byte buffer where the random bytes will be written
+///
+/// # Returns
+/// - `0` (Success) if the bytes were generated successfully
+/// - `1` (EntropyFailure) if random data generation failed
+/// - `2` (InvalidParameter) if out is null
+///
+/// # Safety
+/// The caller must ensure that `out` points to a valid 16-byte buffer.
+#[no_mangle]
+pub extern "C" fn uuid_random_bytes(out: *mut u8) -> c_int {
+    if out.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    let mut bytes = [0u8; 16];
+    match Uuid::fill_random_bytes(&mut bytes) {
+        Ok(()) => {
+            unsafe {
+                let buffer = slice::from_raw_parts_mut(out, 16);
+                buffer.copy_from_slice(&bytes);
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
+    }
+}
+
+/// Generates `count` UUID v4s into a contiguous buffer in a single call
+///
+/// # Parameters
+/// - `uuid_bytes`: Pointer to a buffer of `count * 16` bytes where the UUIDs will be written
+/// - `count`: Number of UUIDs to generate
+///
+/// # Returns
+/// - `0` (Success) if all UUIDs were generated successfully
+/// - `1` (EntropyFailure) if random data generation failed
+/// - `2` (InvalidParameter) if uuid_bytes is null or `count * 16` overflows
+///
+/// # Safety
+/// The caller must ensure that `uuid_bytes` points to a valid buffer of `count * 16` bytes.
+#[no_mangle]
+pub extern "C" fn uuid_generate_v4_batch(uuid_bytes: *mut u8, count: usize) -> c_int {
+    if uuid_bytes.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    let buffer_len = match count.checked_mul(16) {
+        Some(len) => len,
+        None => return UuidFfiError::InvalidParameter as c_int,
+    };
+
+    match Uuid::new_v4_batch(count) {
+        Ok(uuids) => {
+            let buffer = unsafe { slice::from_raw_parts_mut(uuid_bytes, buffer_len) };
+            for (chunk, uuid) in buffer.chunks_exact_mut(16).zip(uuids.iter()) {
+                chunk.copy_from_slice(uuid.as_bytes());
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
     }
 }
 
+/// Allocates a context for repeated UUID v4 generation
+///
+/// The context buffers random data between calls to `uuid_generate_v4_ctx`,
+/// so the entropy source is read far less often than with `uuid_generate_v4`.
+///
+/// # Returns
+/// An opaque pointer that must be released with `uuid_context_free`
+#[no_mangle]
+pub extern "C" fn uuid_context_new() -> *mut UuidContext {
+    Box::into_raw(Box::new(UuidContext::new()))
+}
+
+/// Releases a context returned by `uuid_context_new`
+///
+/// # Parameters
+/// - `ctx`: Pointer previously returned by `uuid_context_new` (null is ignored)
+///
+/// # Safety
+/// The pointer must have been returned by this library and must not be used
+/// after this call.
+#[no_mangle]
+pub extern "C" fn uuid_context_free(ctx: *mut UuidContext) {
+    if ctx.is_null() {
+        return;
+    }
+
+    unsafe {
+        drop(Box::from_raw(ctx));
+    }
+}
+
+/// Generates a new random UUID v4 from a context's buffered random data
+///
+/// # Parameters
+/// - `ctx`: Pointer returned by `uuid_context_new`
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `1` (EntropyFailure) if the buffer needed refilling and random data generation failed
+/// - `2` (InvalidParameter) if ctx or uuid_bytes is null
+///
+/// # Safety
+/// The caller must ensure that `ctx` is a live context not used concurrently
+/// from another thread, and that `uuid_bytes` points to a valid 16-byte buffer.
+#[no_mangle]
+pub extern "C" fn uuid_generate_v4_ctx(ctx: *mut UuidContext, uuid_bytes: *mut u8) -> c_int {
+    if ctx.is_null() || uuid_bytes.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    match unsafe { (*ctx).new_v4() } {
+        Ok(uuid) => {
+            unsafe {
+                let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+                buffer.copy_from_slice(uuid.as_bytes());
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
+    }
+}
+
+/// Generates a new time-ordered UUID v7 and writes the bytes to the provided buffer
+///
+/// # Parameters
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `1` (EntropyFailure) if random data generation failed
+/// - `2` (InvalidParameter) if uuid_bytes is null
+///
+/// # Safety
+/// The caller must ensure that `uuid_bytes` points to a valid 16-byte buffer.
+#[no_mangle]
+pub extern "C" fn uuid_generate_v7(uuid_bytes: *mut u8) -> c_int {
+    if uuid_bytes.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    match Uuid::new_v7() {
+        Ok(uuid) => {
+            unsafe {
+                let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+                buffer.copy_from_slice(uuid.as_bytes());
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
+    }
+}
+
+/// Generates a UUID v7 for the given Unix timestamp and writes the bytes to the provided buffer
+///
+/// # Parameters
+/// - `unix_ms`: Milliseconds since the Unix epoch (must fit in 48 bits)
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `1` (EntropyFailure) if random data generation failed
+/// - `2` (InvalidParameter) if uuid_bytes is null or unix_ms exceeds 48 bits
+///
+/// # Safety
+/// The caller must ensure that `uuid_bytes` points to a valid 16-byte buffer.
+#[no_mangle]
+pub extern "C" fn uuid_generate_v7_at(unix_ms: u64, uuid_bytes: *mut u8) -> c_int {
+    if uuid_bytes.is_null() || unix_ms >> 48 != 0 {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    match Uuid::new_v7_at(unix_ms) {
+        Ok(uuid) => {
+            unsafe {
+                let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+                buffer.copy_from_slice(uuid.as_bytes());
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
+    }
+}
+
+/// Generates a name-based UUID v5 (SHA-1) and writes the bytes to the provided buffer
+///
+/// # Parameters
+/// - `namespace_bytes`: Pointer to the 16-byte namespace UUID
+/// - `name`: Pointer to the name bytes (may be null when `name_len` is 0)
+/// - `name_len`: Length of the name in bytes
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `2` (InvalidParameter) if a required pointer is null
+///
+/// # Safety
+/// The caller must ensure that:
+/// - `namespace_bytes` points to a valid 16-byte UUID
+/// - `name` points to at least `name_len` readable bytes
+/// - `uuid_bytes` points to a valid 16-byte buffer
+#[no_mangle]
+pub extern "C" fn uuid_generate_v5(
+    namespace_bytes: *const u8,
+    name: *const u8,
+    name_len: usize,
+    uuid_bytes: *mut u8,
+) -> c_int {
+    generate_name_based(namespace_bytes, name, name_len, uuid_bytes, Uuid::new_v5)
+}
+
+/// Generates `count` name-based UUID v5s under one namespace in a single call
+///
+/// The names are passed concatenated in `names`, with the length of each one
+/// in `name_lens`. The UUID for the i-th name is written to the i-th 16-byte
+/// record of `uuid_bytes`, and matches what `uuid_generate_v5` returns for it.
+///
+/// # Parameters
+/// - `namespace_bytes`: Pointer to the 16-byte namespace UUID
+/// - `names`: Pointer to the concatenated name bytes (may be null when all names are empty)
+/// - `name_lens`: Pointer to `count` name lengths (may be null when `count` is 0)
+/// - `count`: Number of names
+/// - `uuid_bytes`: Pointer to a buffer of `count * 16` bytes where the UUIDs will be written
+///
+/// # Returns
+/// - `0` (Success) if all UUIDs were generated successfully
+/// - `2` (InvalidParameter) if a required pointer is null or a size overflows
+///
+/// # Safety
+/// The caller must ensure that:
+/// - `namespace_bytes` points to a valid 16-byte UUID
+/// - `name_lens` points to `count` readable lengths
+/// - `names` points to as many readable bytes as the lengths add up to
+/// - `uuid_bytes` points to a valid buffer of `count * 16` bytes
+#[no_mangle]
+pub extern "C" fn uuid_generate_v5_batch(
+    namespace_bytes: *const u8,
+    names: *const u8,
+    name_lens: *const usize,
+    count: usize,
+    uuid_bytes: *mut u8,
+) -> c_int {
+    if namespace_bytes.is_null() || uuid_bytes.is_null() || (name_lens.is_null() && count != 0) {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    let buffer_len = match count.checked_mul(16) {
+        Some(len) => len,
+        None => return UuidFfiError::InvalidParameter as c_int,
+    };
+    if count == 0 {
+        return UuidFfiError::Success as c_int;
+    }
+
+    unsafe {
+        let lens = slice::from_raw_parts(name_lens, count);
+        let total = match lens.iter().try_fold(0usize, |sum, &len| sum.checked_add(len)) {
+            Some(total) => total,
+            None => return UuidFfiError::InvalidParameter as c_int,
+        };
+        if names.is_null() && total != 0 {
+            return UuidFfiError::InvalidParameter as c_int;
+        }
+
+        let mut namespace_array = [0u8; 16];
+        namespace_array.copy_from_slice(slice::from_raw_parts(namespace_bytes, 16));
+        let namespace = Uuid::from_bytes(namespace_array);
+        let all_names = if total == 0 {
+            &[][..]
+        } else {
+            slice::from_raw_parts(names, total)
+        };
+
+        let buffer = slice::from_raw_parts_mut(uuid_bytes, buffer_len);
+        let mut offset = 0;
+        for (chunk, &len) in buffer.chunks_exact_mut(16).zip(lens.iter()) {
+            let uuid = Uuid::new_v5(&namespace, &all_names[offset..offset + len]);
+            chunk.copy_from_slice(uuid.as_bytes());
+            offset += len;
+        }
+    }
+
+    UuidFfiError::Success as c_int
+}
+
+/// Generates a name-based UUID v3 (MD5) and writes the bytes to the provided buffer
+///
+/// # Parameters
+/// - `namespace_bytes`: Pointer to the 16-byte namespace UUID
+/// - `name`: Pointer to the name bytes (may be null when `name_len` is 0)
+/// - `name_len`: Length of the name in bytes
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `2` (InvalidParameter) if a required pointer is null
+///
+/// # Safety
+/// The caller must ensure that:
+/// - `namespace_bytes` points to a valid 16-byte UUID
+/// - `name` points to at least `name_len` readable bytes
+/// - `uuid_bytes` points to a valid 16-byte buffer
+#[no_mangle]
+pub extern "C" fn uuid_generate_v3(
+    namespace_bytes: *const u8,
+    name: *const u8,
+    name_len: usize,
+    uuid_bytes: *mut u8,
+) -> c_int {
+    generate_name_based(namespace_bytes, name, name_len, uuid_bytes, Uuid::new_v3)
+}
+
+/// Generates a custom UUID v8 from the provided bytes
+///
+/// # Parameters
+/// - `data`: Pointer to the 16 application-specific bytes to embed
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if UUID was generated successfully
+/// - `2` (InvalidParameter) if either pointer is null
+///
+/// # Safety
+/// The caller must ensure that:
+/// - `data` points to 16 readable bytes
+/// - `uuid_bytes` points to a valid 16-byte buffer
+#[no_mangle]
+pub extern "C" fn uuid_generate_v8(data: *const u8, uuid_bytes: *mut u8) -> c_int {
+    if data.is_null() || uuid_bytes.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    unsafe {
+        let mut data_array = [0u8; 16];
+        data_array.copy_from_slice(slice::from_raw_parts(data, 16));
+
+        let uuid = Uuid::new_v8(data_array);
+        let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+        buffer.copy_from_slice(uuid.as_bytes());
+    }
+
+    UuidFfiError::Success as c_int
+}
+
+/// Shared implementation of the name-based FFI generators
+fn generate_name_based(
+    namespace_bytes: *const u8,
+    name: *const u8,
+    name_len: usize,
+    uuid_bytes: *mut u8,
+    generate: fn(&Uuid, &[u8]) -> Uuid,
+) -> c_int {
+    if namespace_bytes.is_null() || uuid_bytes.is_null() || (name.is_null() && name_len != 0) {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    unsafe {
+        let mut namespace_array = [0u8; 16];
+        namespace_array.copy_from_slice(slice::from_raw_parts(namespace_bytes, 16));
+        let name_slice = if name_len == 0 {
+            &[][..]
+        } else {
+            slice::from_raw_parts(name, name_len)
+        };
+
+        let uuid = generate(&Uuid::from_bytes(namespace_array), name_slice);
+        let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+        buffer.copy_from_slice(uuid.as_bytes());
+    }
+
+    UuidFfiError::Success as c_int
+}
+
 /// Converts UUID bytes to a null-terminated string representation
 ///
 /// # Parameters
@@ -110,12 +677,46 @@ pub extern "C" fn uuid_to_string(
     uuid_bytes: *const u8,
     uuid_string: *mut c_char,
     buffer_size: usize,
+) -> c_int {
+    uuid_to_string_styled(uuid_bytes, FormatStyle::Canonical as u32, uuid_string, buffer_size)
+}
+
+/// Converts UUID bytes to a null-terminated string in the requested style
+///
+/// # Parameters
+/// - `uuid_bytes`: Pointer to a 16-byte UUID
+/// - `style`: Format style code (`0` canonical, `1` braced, `2` simple, `3` URN, `4` canonical uppercase)
+/// - `uuid_string`: Pointer to a buffer where the string will be written
+/// - `buffer_size`: Size of the string buffer (must fit the style's length plus a null terminator,
+///   i.e. 37 bytes for canonical, 39 bytes for braced, 33 bytes for simple, and 46 bytes for URN)
+///
+/// # Returns
+/// - `0` (Success) if conversion was successful
+/// - `2` (InvalidParameter) if any pointer is null or the style is unknown
+/// - `3` (BufferTooSmall) if the buffer cannot hold the formatted string
+///
+/// # Safety
+/// The caller must ensure that:
+/// - `uuid_bytes` points to a valid 16-byte UUID
+/// - `uuid_string` points to a valid buffer of at least `buffer_size` bytes
+/// - `buffer_size` is accurate
+#[no_mangle]
+pub extern "C" fn uuid_to_string_styled(
+    uuid_bytes: *const u8,
+    style: u32,
+    uuid_string: *mut c_char,
+    buffer_size: usize,
 ) -> c_int {
     if uuid_bytes.is_null() || uuid_string.is_null() {
         return UuidFfiError::InvalidParameter as c_int;
     }
 
-    if buffer_size < 37 {
+    let style = match FormatStyle::from_code(style) {
+        Some(style) => style,
+        None => return UuidFfiError::InvalidParameter as c_int,
+    };
+
+    if buffer_size < style.len() + 1 {
         return UuidFfiError::BufferTooSmall as c_int;
     }
 
@@ -125,9 +726,9 @@ pub extern "C" fn uuid_to_string(
         uuid_array.copy_from_slice(uuid_bytes_slice);
         
         let uuid = Uuid::from_bytes(uuid_array);
-        let uuid_str = format!("{}", uuid);
+        let uuid_str = uuid.to_styled_string(style);
         
-        let uuid_cstring = match std::ffi::CString::new(uuid_str) {
+        let uuid_cstring = match CString::new(uuid_str) {
             Ok(s) => s,
             Err(_) => return UuidFfiError::UnknownError as c_int,
         };
@@ -147,6 +748,108 @@ pub extern "C" fn uuid_to_string(
     UuidFfiError::Success as c_int
 }
 
+/// Converts UUID bytes to a newly allocated null-terminated string in the requested style
+///
+/// Unlike `uuid_to_string_styled` the caller does not need to size a buffer,
+/// but the returned string is owned by this library and must be released with
+/// `uuid_free_string`.
+///
+/// # Parameters
+/// - `uuid_bytes`: Pointer to a 16-byte UUID
+/// - `style`: Format style code (`0` canonical, `1` braced, `2` simple, `3` URN, `4` canonical uppercase)
+///
+/// # Returns
+/// - Pointer to the formatted string on success
+/// - Null if `uuid_bytes` is null or the style is unknown
+///
+/// # Safety
+/// The caller must ensure that `uuid_bytes` points to a valid 16-byte UUID and
+/// that the returned pointer is passed to `uuid_free_string` exactly once.
+#[no_mangle]
+pub extern "C" fn uuid_to_string_alloc(uuid_bytes: *const u8, style: u32) -> *mut c_char {
+    if uuid_bytes.is_null() {
+        return ptr::null_mut();
+    }
+
+    let style = match FormatStyle::from_code(style) {
+        Some(style) => style,
+        None => return ptr::null_mut(),
+    };
+
+    let mut uuid_array = [0u8; 16];
+    uuid_array.copy_from_slice(unsafe { slice::from_raw_parts(uuid_bytes, 16) });
+
+    match CString::new(Uuid::from_bytes(uuid_array).to_styled_string(style)) {
+        Ok(s) => s.into_raw(),
+        Err(_) => ptr::null_mut(),
+    }
+}
+
+/// Releases a string returned by one of the allocating FFI functions
+///
+/// # Parameters
+/// - `uuid_string`: Pointer previously returned by `uuid_to_string_alloc` (null is ignored)
+///
+/// # Safety
+/// The pointer must have been returned by this library and must not be used
+/// after this call.
+#[no_mangle]
+pub extern "C" fn uuid_free_string(uuid_string: *mut c_char) {
+    if uuid_string.is_null() {
+        return;
+    }
+
+    unsafe {
+        drop(CString::from_raw(uuid_string));
+    }
+}
+
+/// Parses a UUID string and writes the bytes to the provided buffer
+///
+/// Accepts the canonical, simple (hyphenless), braced, and URN forms.
+///
+/// # Parameters
+/// - `uuid_string`: Pointer to the string to parse (need not be null-terminated)
+/// - `string_len`: Length of the string in bytes
+/// - `uuid_bytes`: Pointer to a 16-byte buffer where the UUID will be written
+///
+/// # Returns
+/// - `0` (Success) if the string was parsed successfully
+/// - `2` (InvalidParameter) if any pointer is null
+/// - `4` (InvalidFormat) if the string is not in one of the accepted forms
+///
+/// # Safety
+/// The caller must ensure that:
+/// - `uuid_string` points to at least `string_len` readable bytes
+/// - `uuid_bytes` points to a valid 16-byte buffer
+#[no_mangle]
+pub extern "C" fn uuid_from_string(
+    uuid_string: *const c_char,
+    string_len: usize,
+    uuid_bytes: *mut u8,
+) -> c_int {
+    if uuid_string.is_null() || uuid_bytes.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    let input = unsafe { slice::from_raw_parts(uuid_string as *const u8, string_len) };
+    let input = match std::str::from_utf8(input) {
+        Ok(s) => s,
+        Err(_) => return record_error(UuidError::InvalidFormat("string is not valid UTF-8".to_string())),
+    };
+
+    match Uuid::parse_str(input) {
+        Ok(uuid) => {
+            unsafe {
+                let buffer = slice::from_raw_parts_mut(uuid_bytes, 16);
+                buffer.copy_from_slice(uuid.as_bytes());
+            }
+            UuidFfiError::Success as c_int
+        }
+        Err(e) => record_error(e),
+    }
+}
+
 /// Validates UUID bytes and returns version and variant information
 ///
 /// # Parameters
@@ -163,22 +866,66 @@ pub extern "C" fn uuid_to_string(
 #[no_mangle]
 pub extern "C" fn uuid_get_info(
     uuid_bytes: *const u8,
-    version: *mut u8,
-    variant: *mut u8,
+    version: *mut u8,
+    variant: *mut u8,
+) -> c_int {
+    if uuid_bytes.is_null() || version.is_null() || variant.is_null() {
+        return UuidFfiError::InvalidParameter as c_int;
+    }
+
+    unsafe {
+        let uuid_bytes_slice = slice::from_raw_parts(uuid_bytes, 16);
+        let mut uuid_array = [0u8; 16];
+        uuid_array.copy_from_slice(uuid_bytes_slice);
+        
+        let uuid = Uuid::from_bytes(uuid_array);
+        
+        *version = uuid.version();
+        *variant = uuid.variant();
+    }
+
+    UuidFfiError::Success as c_int
+}
+
+/// Extracts the raw timestamp from a time-based UUID
+///
+/// # Parameters
+/// - `uuid_bytes`: Pointer to a 16-byte UUID
+/// - `timestamp`: Pointer to where the timestamp will be written (100ns Gregorian
+///   intervals for v1 and v6, Unix milliseconds for v7; 0 if there is none)
+/// - `has_timestamp`: Pointer to where the result flag will be written (1 if the
+///   UUID version carries a timestamp, 0 if not)
+///
+/// # Returns
+/// - `0` (Success) if extraction was successful
+/// - `2` (InvalidParameter) if any pointer is null
+///
+/// # Safety
+/// The caller must ensure that all pointers are valid.
+#[no_mangle]
+pub extern "C" fn uuid_get_timestamp(
+    uuid_bytes: *const u8,
+    timestamp: *mut u64,
+    has_timestamp: *mut u8,
 ) -> c_int {
-    if uuid_bytes.is_null() || version.is_null() || variant.is_null() {
+    if uuid_bytes.is_null() || timestamp.is_null() || has_timestamp.is_null() {
         return UuidFfiError::InvalidParameter as c_int;
     }
 
     unsafe {
-        let uuid_bytes_slice = slice::from_raw_parts(uuid_bytes, 16);
         let mut uuid_array = [0u8; 16];
-        uuid_array.copy_from_slice(uuid_bytes_slice);
-        
-        let uuid = Uuid::from_bytes(uuid_array);
-        
-        *version = uuid.version();
-        *variant = uuid.variant();
+        uuid_array.copy_from_slice(slice::from_raw_parts(uuid_bytes, 16));
+
+        match Uuid::from_bytes(uuid_array).timestamp() {
+            Some(value) => {
+                *timestamp = value;
+                *has_timestamp = 1;
+            }
+            None => {
+                *timestamp = 0;
+                *has_timestamp = 0;
+            }
+        }
     }
 
     UuidFfiError::Success as c_int
@@ -250,6 +997,322 @@ mod tests {
         assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
     }
 
+    #[test]
+    fn test_ffi_uuid_generate_v1() {
+        let mut uuid_bytes = [0u8; 16];
+        let result = uuid_generate_v1(uuid_bytes.as_mut_ptr());
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        
+        let uuid = Uuid::from_bytes(uuid_bytes);
+        assert_eq!(uuid.version(), 1);
+        assert_eq!(uuid.variant(), 2);
+        
+        let result = uuid_generate_v1(ptr::null_mut());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v1_with_node() {
+        let node = [0xde, 0xad, 0xbe, 0xef, 0x00, 0x01];
+        let mut uuid_bytes = [0u8; 16];
+        let result = uuid_generate_v1_with_node(node.as_ptr(), uuid_bytes.as_mut_ptr());
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        assert_eq!(&uuid_bytes[10..], &node);
+        assert_eq!(Uuid::from_bytes(uuid_bytes).version(), 1);
+        
+        let result = uuid_generate_v1_with_node(ptr::null(), uuid_bytes.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v2() {
+        let mut uuid_bytes = [0u8; 16];
+        let result = uuid_generate_v2(0, 501, uuid_bytes.as_mut_ptr());
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        assert_eq!(Uuid::from_bytes(uuid_bytes).version(), 2);
+        assert_eq!(&uuid_bytes[..4], &501u32.to_be_bytes());
+        assert_eq!(uuid_bytes[9], 0);
+        
+        let result = uuid_generate_v2(0, 501, ptr::null_mut());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v6_sorts_in_creation_order() {
+        let mut previous = String::new();
+        
+        for _ in 0..1000 {
+            let mut uuid_bytes = [0u8; 16];
+            let result = uuid_generate_v6(uuid_bytes.as_mut_ptr());
+            assert_eq!(result, UuidFfiError::Success as c_int);
+            assert_eq!(Uuid::from_bytes(uuid_bytes).version(), 6);
+            
+            let uuid_str = Uuid::from_bytes(uuid_bytes).to_string();
+            assert!(uuid_str > previous, "{} sorted before {}", uuid_str, previous);
+            previous = uuid_str;
+        }
+        
+        let result = uuid_generate_v6(ptr::null_mut());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_random_bytes() {
+        // With version stamping skipped, the version nibble of 64 random
+        // samples should not be the same every time
+        let mut versions = std::collections::HashSet::new();
+        for _ in 0..64 {
+            let mut bytes = [0u8; 16];
+            let result = uuid_random_bytes(bytes.as_mut_ptr());
+            assert_eq!(result, UuidFfiError::Success as c_int);
+            assert_ne!(bytes, [0u8; 16]);
+            versions.insert(bytes[6] >> 4);
+        }
+        assert!(versions.len() > 1);
+        
+        let result = uuid_random_bytes(ptr::null_mut());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v4_batch() {
+        let mut buffer = [0u8; 16 * 8];
+        let result = uuid_generate_v4_batch(buffer.as_mut_ptr(), 8);
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        
+        for chunk in buffer.chunks_exact(16) {
+            let mut uuid_bytes = [0u8; 16];
+            uuid_bytes.copy_from_slice(chunk);
+            let uuid = Uuid::from_bytes(uuid_bytes);
+            assert_eq!(uuid.version(), 4);
+            assert_eq!(uuid.variant(), 2);
+        }
+        
+        assert_ne!(&buffer[..16], &buffer[16..32]);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v4_batch_invalid() {
+        let result = uuid_generate_v4_batch(ptr::null_mut(), 1);
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+        
+        let mut buffer = [0u8; 16];
+        let result = uuid_generate_v4_batch(buffer.as_mut_ptr(), usize::MAX);
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v7() {
+        let mut uuid_bytes = [0u8; 16];
+        let result = uuid_generate_v7(uuid_bytes.as_mut_ptr());
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        
+        let uuid = Uuid::from_bytes(uuid_bytes);
+        assert_eq!(uuid.version(), 7);
+        assert_eq!(uuid.variant(), 2);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v7_monotonic() {
+        let mut previous = String::new();
+        
+        for _ in 0..1000 {
+            let mut uuid_bytes = [0u8; 16];
+            let result = uuid_generate_v7(uuid_bytes.as_mut_ptr());
+            assert_eq!(result, UuidFfiError::Success as c_int);
+            
+            let mut buffer = [0i8; 37];
+            let result = uuid_to_string(uuid_bytes.as_ptr(), buffer.as_mut_ptr(), buffer.len());
+            assert_eq!(result, UuidFfiError::Success as c_int);
+            
+            let c_str = unsafe { CStr::from_ptr(buffer.as_ptr()) };
+            let uuid_str = c_str.to_str().unwrap().to_string();
+            assert!(uuid_str >= previous, "{} sorted before {}", uuid_str, previous);
+            previous = uuid_str;
+        }
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v7_null_pointer() {
+        let result = uuid_generate_v7(ptr::null_mut());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v7_at() {
+        let mut uuid_bytes = [0u8; 16];
+        let result = uuid_generate_v7_at(1_645_557_742_000, uuid_bytes.as_mut_ptr());
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        assert_eq!(Uuid::from_bytes(uuid_bytes).timestamp(), Some(1_645_557_742_000));
+        
+        let result = uuid_generate_v7_at(1 << 48, uuid_bytes.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+        
+        let result = uuid_generate_v7_at(0, ptr::null_mut());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_context() {
+        let ctx = uuid_context_new();
+        assert!(!ctx.is_null());
+        
+        let mut first = [0u8; 16];
+        let mut second = [0u8; 16];
+        assert_eq!(uuid_generate_v4_ctx(ctx, first.as_mut_ptr()), UuidFfiError::Success as c_int);
+        assert_eq!(uuid_generate_v4_ctx(ctx, second.as_mut_ptr()), UuidFfiError::Success as c_int);
+        assert_eq!(Uuid::from_bytes(first).version(), 4);
+        assert_ne!(first, second);
+        
+        let result = uuid_generate_v4_ctx(ctx, ptr::null_mut());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+        let result = uuid_generate_v4_ctx(ptr::null_mut(), first.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+        
+        uuid_context_free(ctx);
+        uuid_context_free(ptr::null_mut());
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v5() {
+        let namespace = Uuid::parse_str("6ba7b810-9dad-11d1-80b4-00c04fd430c8").unwrap();
+        let name = b"python.org";
+        
+        let mut first = [0u8; 16];
+        let mut second = [0u8; 16];
+        for out in [&mut first, &mut second].iter_mut() {
+            let result = uuid_generate_v5(
+                namespace.as_bytes().as_ptr(),
+                name.as_ptr(),
+                name.len(),
+                out.as_mut_ptr(),
+            );
+            assert_eq!(result, UuidFfiError::Success as c_int);
+        }
+        
+        assert_eq!(first, second);
+        let uuid = Uuid::from_bytes(first);
+        assert_eq!(uuid.to_string(), "886313e1-3b8a-5372-9b90-0c9aee199e5d");
+        assert_eq!(uuid.version(), 5);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v5_null_pointers() {
+        let namespace = [0u8; 16];
+        let mut uuid_bytes = [0u8; 16];
+        
+        // An empty name may be passed as null
+        let result = uuid_generate_v5(namespace.as_ptr(), ptr::null(), 0, uuid_bytes.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        
+        let result = uuid_generate_v5(namespace.as_ptr(), ptr::null(), 4, uuid_bytes.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+        
+        let result = uuid_generate_v5(ptr::null(), ptr::null(), 0, uuid_bytes.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v5_batch_matches_single() {
+        let namespace = Uuid::parse_str("6ba7b810-9dad-11d1-80b4-00c04fd430c8").unwrap();
+        let names: [&[u8]; 3] = [b"python.org", b"", b"example.com"];
+        let joined = names.concat();
+        let lens: Vec<usize> = names.iter().map(|name| name.len()).collect();
+        
+        let mut batch = [0u8; 48];
+        let result = uuid_generate_v5_batch(
+            namespace.as_bytes().as_ptr(),
+            joined.as_ptr(),
+            lens.as_ptr(),
+            names.len(),
+            batch.as_mut_ptr(),
+        );
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        
+        for (chunk, name) in batch.chunks_exact(16).zip(names.iter()) {
+            assert_eq!(chunk, Uuid::new_v5(&namespace, name).as_bytes());
+        }
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v5_batch_null_pointers() {
+        let namespace = [0u8; 16];
+        let lens = [4usize];
+        let mut uuid_bytes = [0u8; 16];
+        
+        let result = uuid_generate_v5_batch(namespace.as_ptr(), ptr::null(), ptr::null(), 0, uuid_bytes.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        
+        let result = uuid_generate_v5_batch(namespace.as_ptr(), ptr::null(), lens.as_ptr(), 1, uuid_bytes.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+        
+        let result = uuid_generate_v5_batch(namespace.as_ptr(), ptr::null(), ptr::null(), 1, uuid_bytes.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v3() {
+        let namespace = Uuid::parse_str("6ba7b810-9dad-11d1-80b4-00c04fd430c8").unwrap();
+        let name = b"example.com";
+        
+        let mut uuid_bytes = [0u8; 16];
+        let result = uuid_generate_v3(
+            namespace.as_bytes().as_ptr(),
+            name.as_ptr(),
+            name.len(),
+            uuid_bytes.as_mut_ptr(),
+        );
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        let uuid = Uuid::from_bytes(uuid_bytes);
+        assert_eq!(uuid.to_string(), "9073926b-929f-31c2-abc9-fad77ae3e8eb");
+        assert_eq!(uuid.version(), 3);
+    }
+
+    #[test]
+    fn test_ffi_uuid_generate_v8() {
+        let data = [0u8; 16];
+        let mut uuid_bytes = [0u8; 16];
+        let result = uuid_generate_v8(data.as_ptr(), uuid_bytes.as_mut_ptr());
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        let uuid = Uuid::from_bytes(uuid_bytes);
+        assert_eq!(uuid.to_string(), "00000000-0000-8000-8000-000000000000");
+        
+        let result = uuid_generate_v8(ptr::null(), uuid_bytes.as_mut_ptr());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_last_error() {
+        let code = record_error(UuidError::EntropyError("Failed to open /dev/urandom: denied".to_string()));
+        assert_eq!(code, UuidFfiError::EntropyFailure as c_int);
+        
+        let mut buffer = [0i8; 64];
+        let result = uuid_last_error(buffer.as_mut_ptr(), buffer.len());
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        let c_str = unsafe { CStr::from_ptr(buffer.as_ptr()) };
+        assert_eq!(c_str.to_str().unwrap(), "Failed to open /dev/urandom: denied");
+        
+        let result = uuid_last_error(buffer.as_mut_ptr(), 8);
+        assert_eq!(result, UuidFfiError::BufferTooSmall as c_int);
+        
+        // Details are tracked per thread
+        std::thread::spawn(|| {
+            let mut buffer = [1i8; 8];
+            assert_eq!(uuid_last_error(buffer.as_mut_ptr(), buffer.len()), UuidFfiError::Success as c_int);
+            assert_eq!(buffer[0], 0);
+        })
+        .join()
+        .unwrap();
+    }
+
     #[test]
     fn test_ffi_uuid_to_string() {
         let mut uuid_bytes = [0u8; 16];
@@ -288,6 +1351,184 @@ mod tests {
         assert_eq!(result, UuidFfiError::BufferTooSmall as c_int);
     }
 
+    #[test]
+    fn test_ffi_uuid_to_string_styled_braced() {
+        let uuid = Uuid::parse_str("550e8400-e29b-41d4-a716-446655440000").unwrap();
+        
+        let mut buffer = [0i8; 39];
+        let result = uuid_to_string_styled(
+            uuid.as_bytes().as_ptr(),
+            FormatStyle::Braced as u32,
+            buffer.as_mut_ptr(),
+            buffer.len(),
+        );
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        let c_str = unsafe { CStr::from_ptr(buffer.as_ptr()) };
+        assert_eq!(c_str.to_str().unwrap(), "{550e8400-e29b-41d4-a716-446655440000}");
+        
+        // The canonical buffer size is two bytes short for the braced form
+        let mut buffer = [0i8; 37];
+        let result = uuid_to_string_styled(
+            uuid.as_bytes().as_ptr(),
+            FormatStyle::Braced as u32,
+            buffer.as_mut_ptr(),
+            buffer.len(),
+        );
+        assert_eq!(result, UuidFfiError::BufferTooSmall as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_to_string_styled_simple() {
+        let mut uuid_bytes = [0u8; 16];
+        uuid_generate_v4(uuid_bytes.as_mut_ptr());
+        
+        let mut buffer = [0i8; 33];
+        let result = uuid_to_string_styled(
+            uuid_bytes.as_ptr(),
+            FormatStyle::Simple as u32,
+            buffer.as_mut_ptr(),
+            buffer.len(),
+        );
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        let c_str = unsafe { CStr::from_ptr(buffer.as_ptr()) };
+        let simple = c_str.to_str().unwrap();
+        assert_eq!(simple.len(), 32);
+        assert!(simple.chars().all(|c| c.is_ascii_hexdigit()));
+        assert_eq!(simple, Uuid::from_bytes(uuid_bytes).to_string().replace('-', ""));
+    }
+
+    #[test]
+    fn test_ffi_uuid_to_string_styled_urn() {
+        let uuid = Uuid::parse_str("550e8400-e29b-41d4-a716-446655440000").unwrap();
+        
+        let mut buffer = [0i8; 46];
+        let result = uuid_to_string_styled(
+            uuid.as_bytes().as_ptr(),
+            FormatStyle::Urn as u32,
+            buffer.as_mut_ptr(),
+            buffer.len(),
+        );
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        let c_str = unsafe { CStr::from_ptr(buffer.as_ptr()) };
+        let urn = c_str.to_str().unwrap();
+        assert_eq!(urn, "urn:uuid:550e8400-e29b-41d4-a716-446655440000");
+        assert_eq!(Uuid::parse_str(urn.strip_prefix("urn:uuid:").unwrap()), Ok(uuid));
+        
+        let result = uuid_to_string_styled(
+            uuid.as_bytes().as_ptr(),
+            FormatStyle::Urn as u32,
+            buffer.as_mut_ptr(),
+            45,
+        );
+        assert_eq!(result, UuidFfiError::BufferTooSmall as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_to_string_styled_unknown_style() {
+        let uuid_bytes = [0u8; 16];
+        let mut buffer = [0i8; 64];
+        
+        let result = uuid_to_string_styled(uuid_bytes.as_ptr(), 99, buffer.as_mut_ptr(), buffer.len());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
+    #[test]
+    fn test_ffi_uuid_to_string_alloc() {
+        let uuid = Uuid::parse_str("550e8400-e29b-41d4-a716-446655440000").unwrap();
+        
+        let uuid_string = uuid_to_string_alloc(uuid.as_bytes().as_ptr(), FormatStyle::Urn as u32);
+        assert!(!uuid_string.is_null());
+        let c_str = unsafe { CStr::from_ptr(uuid_string) };
+        assert_eq!(c_str.to_str().unwrap(), "urn:uuid:550e8400-e29b-41d4-a716-446655440000");
+        uuid_free_string(uuid_string);
+        
+        assert!(uuid_to_string_alloc(ptr::null(), 0).is_null());
+        assert!(uuid_to_string_alloc(uuid.as_bytes().as_ptr(), 99).is_null());
+        uuid_free_string(ptr::null_mut());
+    }
+
+    #[test]
+    fn test_ffi_uuid_to_string_alloc_repeated() {
+        let mut uuid_bytes = [0u8; 16];
+        uuid_generate_v4(uuid_bytes.as_mut_ptr());
+        
+        for i in 0..100_000 {
+            let uuid_string = uuid_to_string_alloc(uuid_bytes.as_ptr(), (i % 4) as u32);
+            assert!(!uuid_string.is_null());
+            uuid_free_string(uuid_string);
+        }
+    }
+
+    #[test]
+    fn test_ffi_uuid_from_string() {
+        let input = "550e8400-e29b-41d4-a716-446655440000";
+        let mut uuid_bytes = [0u8; 16];
+        let result = uuid_from_string(
+            input.as_ptr() as *const c_char,
+            input.len(),
+            uuid_bytes.as_mut_ptr(),
+        );
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        assert_eq!(format!("{}", Uuid::from_bytes(uuid_bytes)), input);
+        
+        let simple = "550e8400e29b41d4a716446655440000";
+        let mut simple_bytes = [0u8; 16];
+        let result = uuid_from_string(
+            simple.as_ptr() as *const c_char,
+            simple.len(),
+            simple_bytes.as_mut_ptr(),
+        );
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        assert_eq!(simple_bytes, uuid_bytes);
+    }
+
+    #[test]
+    fn test_ffi_uuid_from_string_ignores_case() {
+        let inputs = [
+            "550e8400-e29b-41d4-a716-446655440abc",
+            "550E8400-E29B-41D4-A716-446655440ABC",
+            "550e8400-E29b-41D4-a716-446655440aBc",
+        ];
+        
+        let mut parsed = [[0u8; 16]; 3];
+        for (input, out) in inputs.iter().zip(parsed.iter_mut()) {
+            let result = uuid_from_string(input.as_ptr() as *const c_char, input.len(), out.as_mut_ptr());
+            assert_eq!(result, UuidFfiError::Success as c_int, "input {:?}", input);
+        }
+        
+        assert_eq!(parsed[0], parsed[1]);
+        assert_eq!(parsed[0], parsed[2]);
+    }
+
+    #[test]
+    fn test_ffi_uuid_from_string_invalid() {
+        let cases: [&[u8]; 6] = [
+            b"",
+            b"550e8400e29b41d4a716446655440000a",
+            b"550e8400-e29b-41d4-a716-44665544000",
+            b"550e8400-e29b-41d4-a716-44665544000z",
+            b"550e8400+e29b-41d4-a716-446655440000",
+            b"550e8400-e29b-41d4-a716-44665544\0000",
+        ];
+        
+        for input in cases.iter() {
+            let mut uuid_bytes = [0u8; 16];
+            let result = uuid_from_string(
+                input.as_ptr() as *const c_char,
+                input.len(),
+                uuid_bytes.as_mut_ptr(),
+            );
+            assert_eq!(result, UuidFfiError::InvalidFormat as c_int, "input {:?}", input);
+        }
+        
+        let result = uuid_from_string(ptr::null(), 0, [0u8; 16].as_mut_ptr());
+        assert_eq!(result, UuidFfiError::InvalidParameter as c_int);
+    }
+
     #[test]
     fn test_ffi_uuid_get_info() {
         let mut uuid_bytes = [0u8; 16];
@@ -308,6 +1549,26 @@ mod tests {
         assert_eq!(variant, 2);
     }
 
+    #[test]
+    fn test_ffi_uuid_get_timestamp() {
+        let mut uuid_bytes = [0u8; 16];
+        uuid_generate_v7(uuid_bytes.as_mut_ptr());
+        
+        let mut timestamp = 0u64;
+        let mut has_timestamp = 0u8;
+        let result = uuid_get_timestamp(uuid_bytes.as_ptr(), &mut timestamp, &mut has_timestamp);
+        
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        assert_eq!(has_timestamp, 1);
+        assert_eq!(Some(timestamp), Uuid::from_bytes(uuid_bytes).timestamp());
+        
+        uuid_generate_v4(uuid_bytes.as_mut_ptr());
+        let result = uuid_get_timestamp(uuid_bytes.as_ptr(), &mut timestamp, &mut has_timestamp);
+        assert_eq!(result, UuidFfiError::Success as c_int);
+        assert_eq!(has_timestamp, 0);
+        assert_eq!(timestamp, 0);
+    }
+
     #[test]
     fn test_ffi_uuid_compare() {
         let mut uuid1_bytes = [0u8; 16];
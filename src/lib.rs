@@ -22,10 +22,43 @@
 //! ```
 
 pub mod ffi;
+mod hash;
 
 use std::fmt;
 use std::fs::File;
 use std::io::Read;
+use std::sync::Mutex;
+use std::time::{SystemTime, UNIX_EPOCH};
+
+
+/// Number of 100-nanosecond intervals between the Gregorian epoch used by
+/// time-based UUIDs (1582-10-15 00:00:00 UTC) and the Unix epoch
+const GREGORIAN_OFFSET: u64 = 0x01b2_1dd2_1381_4000;
+
+/// Process-wide state for UUID v1 generation
+struct V1State {
+    /// Timestamp of the last UUID v1 handed out, in 100ns Gregorian intervals
+    last_timestamp: u64,
+    /// Random 14-bit clock sequence chosen when the state is created
+    clock_seq: u16,
+    /// Random node identifier with the multicast bit set
+    node: [u8; 6],
+}
+
+static V1_STATE: Mutex<Option<V1State>> = Mutex::new(None);
+
+/// Process-wide state for UUID v7 generation
+struct V7State {
+    /// Millisecond timestamp of the last UUID v7 handed out
+    last_unix_ms: u64,
+    /// 12-bit sequence stored in the `rand_a` field of the last UUID v7
+    counter: u16,
+}
+
+static V7_STATE: Mutex<Option<V7State>> = Mutex::new(None);
+
+/// Largest value of the 12-bit UUID v7 counter
+const V7_COUNTER_MAX: u16 = 0x0fff;
 
 /// UUID structure representing a 128-bit universally unique identifier
 /// 
@@ -63,6 +96,48 @@ impl fmt::Display for UuidError {
 
 impl std::error::Error for UuidError {}
 
+/// String representations a UUID can be formatted as
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum FormatStyle {
+    /// Canonical 8-4-4-4-12 form: `550e8400-e29b-41d4-a716-446655440000`
+    Canonical,
+    /// Microsoft GUID form with braces: `{550e8400-e29b-41d4-a716-446655440000}`
+    Braced,
+    /// Hyphenless 32 hex digit form: `550e8400e29b41d4a716446655440000`
+    Simple,
+    /// RFC 4122 URN form: `urn:uuid:550e8400-e29b-41d4-a716-446655440000`
+    Urn,
+    /// Canonical form with uppercase hex digits: `550E8400-E29B-41D4-A716-446655440000`
+    CanonicalUpper,
+}
+
+impl FormatStyle {
+    /// Returns the style for a numeric code as used by the FFI layer
+    /// 
+    /// # Returns
+    /// `Some(FormatStyle)` for a known code, `None` otherwise
+    pub fn from_code(code: u32) -> Option<Self> {
+        match code {
+            0 => Some(FormatStyle::Canonical),
+            1 => Some(FormatStyle::Braced),
+            2 => Some(FormatStyle::Simple),
+            3 => Some(FormatStyle::Urn),
+            4 => Some(FormatStyle::CanonicalUpper),
+            _ => None,
+        }
+    }
+
+    /// Returns the length in characters of a UUID formatted in this style
+    pub fn len(self) -> usize {
+        match self {
+            FormatStyle::Canonical | FormatStyle::CanonicalUpper => 36,
+            FormatStyle::Braced => 38,
+            FormatStyle::Simple => 32,
+            FormatStyle::Urn => 45,
+        }
+    }
+}
+
 impl Uuid {
     /// Creates a new UUID v4 using cryptographically secure random data
     /// 
@@ -100,6 +175,422 @@ impl Uuid {
         })
     }
     
+    /// Creates `count` UUID v4s using a single read from the entropy source
+    /// 
+    /// Equivalent to calling `new_v4` `count` times, but collects all the
+    /// random data up front which is considerably cheaper for large batches.
+    /// 
+    /// # Arguments
+    /// - `count` - Number of UUIDs to generate
+    /// 
+    /// # Returns
+    /// - `Ok(Vec<Uuid>)` - `count` newly generated UUID v4s
+    /// - `Err(UuidError)` - If entropy collection fails
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let uuids = Uuid::new_v4_batch(100).expect("Failed to generate UUIDs");
+    /// assert_eq!(uuids.len(), 100);
+    /// ```
+    pub fn new_v4_batch(count: usize) -> Result<Vec<Self>, UuidError> {
+        let mut random_bytes = vec![0u8; count * 16];
+        Self::fill_random_bytes(&mut random_bytes)?;
+
+        Ok(random_bytes
+            .chunks_exact(16)
+            .map(|chunk| {
+                let mut bytes = [0u8; 16];
+                bytes.copy_from_slice(chunk);
+                bytes[6] = (bytes[6] & 0x0f) | 0x40;
+                bytes[8] = (bytes[8] & 0x3f) | 0x80;
+                Uuid { bytes }
+            })
+            .collect())
+    }
+    
+    /// Creates a new time-based UUID v1
+    /// 
+    /// This function demonstrates the UUID v1 generation process:
+    /// 1. Take the current time as 100-nanosecond intervals since 1582-10-15
+    /// 2. Split the 60-bit timestamp into time_low, time_mid, and time_hi fields
+    /// 3. Set the version field (bits 48-51) to 0b0001 (1)
+    /// 4. Add the 14-bit clock sequence with the variant field set to 0b10
+    /// 5. Append the 48-bit node identifier
+    /// 
+    /// No hardware address is read; instead a random node is chosen once per
+    /// process with the multicast bit set, as RFC 4122 section 4.5 requires for
+    /// nodes that are not IEEE 802 addresses. If the clock has not advanced since
+    /// the previous UUID v1, the timestamp is bumped by one interval so values
+    /// stay unique and increasing.
+    /// 
+    /// # Returns
+    /// - `Ok(Uuid)` - A newly generated UUID v1
+    /// - `Err(UuidError)` - If entropy collection fails
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let uuid = Uuid::new_v1().expect("Failed to generate UUID");
+    /// assert_eq!(uuid.version(), 1);
+    /// ```
+    pub fn new_v1() -> Result<Self, UuidError> {
+        let (timestamp, clock_seq, node) = Self::next_v1_fields()?;
+        Ok(Self::from_v1_fields(timestamp, clock_seq, node))
+    }
+
+    /// Creates a new time-based UUID v1 with the given node identifier
+    /// 
+    /// Identical to `new_v1` except that `node`, typically the host's IEEE 802
+    /// MAC address, is stored unchanged in the last six bytes instead of the
+    /// random per-process node.
+    /// 
+    /// # Arguments
+    /// - `node` - 48-bit node identifier
+    /// 
+    /// # Returns
+    /// - `Ok(Uuid)` - A newly generated UUID v1
+    /// - `Err(UuidError)` - If entropy collection for the clock sequence fails
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let node = [0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e];
+    /// let uuid = Uuid::new_v1_with_node(node).expect("Failed to generate UUID");
+    /// assert_eq!(&uuid.as_bytes()[10..], &node);
+    /// ```
+    pub fn new_v1_with_node(node: [u8; 6]) -> Result<Self, UuidError> {
+        let (timestamp, clock_seq, _) = Self::next_v1_fields()?;
+        Ok(Self::from_v1_fields(timestamp, clock_seq, node))
+    }
+
+    /// Advances the process-wide v1 state and returns the timestamp, clock
+    /// sequence, and default node to use for the next UUID v1
+    fn next_v1_fields() -> Result<(u64, u16, [u8; 6]), UuidError> {
+        let now = SystemTime::now()
+            .duration_since(UNIX_EPOCH)
+            .map(|d| (d.as_nanos() / 100) as u64)
+            .unwrap_or(0)
+            + GREGORIAN_OFFSET;
+
+        let mut state = V1_STATE.lock().unwrap_or_else(|e| e.into_inner());
+        let state = match &mut *state {
+            Some(state) => state,
+            None => {
+                let mut random = [0u8; 8];
+                Self::fill_random_bytes(&mut random)?;
+
+                let mut node = [0u8; 6];
+                node.copy_from_slice(&random[2..]);
+                node[0] |= 0x01;
+
+                state.insert(V1State {
+                    last_timestamp: 0,
+                    clock_seq: u16::from_be_bytes([random[0], random[1]]) & 0x3fff,
+                    node,
+                })
+            }
+        };
+
+        let timestamp = if now > state.last_timestamp {
+            now
+        } else {
+            state.last_timestamp + 1
+        };
+        state.last_timestamp = timestamp;
+
+        Ok((timestamp, state.clock_seq, state.node))
+    }
+
+    /// Creates a new DCE Security UUID v2
+    /// 
+    /// UUID v2 is a UUID v1 in which some fields are repurposed:
+    /// 1. Replace time_low with the 32-bit local identifier (e.g. a POSIX UID)
+    /// 2. Set the version field (bits 48-51) to 0b0010 (2)
+    /// 3. Replace clock_seq_low with the local domain (0 person, 1 group, 2 org)
+    /// 
+    /// The remaining time, clock sequence, and node fields come from the same
+    /// state as `new_v1`.
+    /// 
+    /// # Arguments
+    /// - `domain` - Local domain the identifier belongs to
+    /// - `id` - Local identifier within the domain
+    /// 
+    /// # Returns
+    /// - `Ok(Uuid)` - A newly generated UUID v2
+    /// - `Err(UuidError)` - If entropy collection fails
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let uuid = Uuid::new_v2(0, 1000).expect("Failed to generate UUID");
+    /// assert_eq!(uuid.version(), 2);
+    /// assert_eq!(&uuid.as_bytes()[..4], &1000u32.to_be_bytes());
+    /// ```
+    pub fn new_v2(domain: u8, id: u32) -> Result<Self, UuidError> {
+        let (timestamp, clock_seq, node) = Self::next_v1_fields()?;
+        let mut bytes = Self::from_v1_fields(timestamp, clock_seq, node).bytes;
+        bytes[0..4].copy_from_slice(&id.to_be_bytes());
+        bytes[6] = (bytes[6] & 0x0f) | 0x20;
+        bytes[9] = domain;
+
+        Ok(Uuid { bytes })
+    }
+
+    /// Lays out the fields of a UUID v1 in big-endian order
+    fn from_v1_fields(timestamp: u64, clock_seq: u16, node: [u8; 6]) -> Self {
+        let mut bytes = [0u8; 16];
+        bytes[0..4].copy_from_slice(&(timestamp as u32).to_be_bytes());
+        bytes[4..6].copy_from_slice(&((timestamp >> 32) as u16).to_be_bytes());
+        bytes[6..8].copy_from_slice(&(((timestamp >> 48) as u16 & 0x0fff) | 0x1000).to_be_bytes());
+        bytes[8] = ((clock_seq >> 8) as u8 & 0x3f) | 0x80;
+        bytes[9] = clock_seq as u8;
+        bytes[10..16].copy_from_slice(&node);
+
+        Uuid { bytes }
+    }
+    
+    /// Creates a new reordered time-based UUID v6
+    /// 
+    /// UUID v6 carries the same fields as v1 but stores the timestamp most
+    /// significant bits first, so values sort in creation order:
+    /// 1. Take the current time as 100-nanosecond intervals since 1582-10-15
+    /// 2. Store the high 48 bits of the 60-bit timestamp in bytes 0-5
+    /// 3. Set the version field (bits 48-51) to 0b0110 (6), followed by the
+    ///    low 12 bits of the timestamp
+    /// 4. Add the 14-bit clock sequence with the variant field set to 0b10
+    /// 5. Append the 48-bit node identifier
+    /// 
+    /// The clock sequence, node, and timestamp state are shared with
+    /// `new_v1`.
+    /// 
+    /// # Returns
+    /// - `Ok(Uuid)` - A newly generated UUID v6
+    /// - `Err(UuidError)` - If entropy collection fails
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let uuid = Uuid::new_v6().expect("Failed to generate UUID");
+    /// assert_eq!(uuid.version(), 6);
+    /// ```
+    pub fn new_v6() -> Result<Self, UuidError> {
+        let (timestamp, clock_seq, node) = Self::next_v1_fields()?;
+        Ok(Self::from_v6_fields(timestamp, clock_seq, node))
+    }
+
+    /// Lays out the fields of a UUID v6 in big-endian order
+    fn from_v6_fields(timestamp: u64, clock_seq: u16, node: [u8; 6]) -> Self {
+        let mut bytes = [0u8; 16];
+        bytes[0..4].copy_from_slice(&((timestamp >> 28) as u32).to_be_bytes());
+        bytes[4..6].copy_from_slice(&((timestamp >> 12) as u16).to_be_bytes());
+        bytes[6..8].copy_from_slice(&((timestamp as u16 & 0x0fff) | 0x6000).to_be_bytes());
+        bytes[8] = ((clock_seq >> 8) as u8 & 0x3f) | 0x80;
+        bytes[9] = clock_seq as u8;
+        bytes[10..16].copy_from_slice(&node);
+
+        Uuid { bytes }
+    }
+    
+    /// Creates a new UUID v7 from the current Unix timestamp and random data
+    /// 
+    /// UUID v7 values are time-ordered, which keeps database indexes compact:
+    /// 1. Encode the Unix timestamp in milliseconds into the first 48 bits
+    /// 2. Use the 12 `rand_a` bits as a per-millisecond counter
+    /// 3. Fill the remaining 62 bits with cryptographically secure random data
+    /// 4. Set the version field (bits 48-51) to 0b0111 (7)
+    /// 5. Set the variant field (bits 64-65) to 0b10
+    /// 
+    /// This is the monotonic counter of RFC 9562 section 6.2, method 3. The
+    /// first UUID in a millisecond seeds the counter randomly with its top bit
+    /// clear; later UUIDs in the same millisecond (or after the clock steps
+    /// backwards) increment it, so values from this process strictly increase.
+    /// If the counter overflows, the timestamp is advanced by one millisecond
+    /// and the counter is reseeded.
+    /// 
+    /// # Returns
+    /// - `Ok(Uuid)` - A newly generated UUID v7
+    /// - `Err(UuidError)` - If entropy collection fails
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let first = Uuid::new_v7().expect("Failed to generate UUID");
+    /// let second = Uuid::new_v7().expect("Failed to generate UUID");
+    /// assert_eq!(first.version(), 7);
+    /// assert!(second.as_bytes() > first.as_bytes());
+    /// ```
+    pub fn new_v7() -> Result<Self, UuidError> {
+        let now_ms = SystemTime::now()
+            .duration_since(UNIX_EPOCH)
+            .map(|d| d.as_millis() as u64)
+            .unwrap_or(0);
+
+        let mut bytes = [0u8; 16];
+        Self::fill_random_bytes(&mut bytes)?;
+        let seed = u16::from_be_bytes([bytes[6], bytes[7]]) & (V7_COUNTER_MAX >> 1);
+
+        let mut state = V7_STATE.lock().unwrap_or_else(|e| e.into_inner());
+        let (unix_ms, counter) = match state.as_ref() {
+            Some(previous) if now_ms <= previous.last_unix_ms => {
+                if previous.counter < V7_COUNTER_MAX {
+                    (previous.last_unix_ms, previous.counter + 1)
+                } else {
+                    (previous.last_unix_ms + 1, seed)
+                }
+            }
+            _ => (now_ms, seed),
+        };
+        *state = Some(V7State { last_unix_ms: unix_ms, counter });
+        drop(state);
+
+        // Step 1: Big-endian 48-bit millisecond timestamp in bytes 0-5
+        let timestamp = unix_ms.to_be_bytes();
+        bytes[..6].copy_from_slice(&timestamp[2..]);
+
+        // Steps 2 and 4: Counter in the low 12 bits of bytes 6-7 under version 7
+        bytes[6] = 0x70 | (counter >> 8) as u8;
+        bytes[7] = counter as u8;
+
+        // Step 5: RFC 4122 variant
+        bytes[8] = (bytes[8] & 0x3f) | 0x80;
+
+        Ok(Uuid { bytes })
+    }
+    
+    /// Creates a UUID v7 for a caller-supplied Unix timestamp
+    /// 
+    /// Unlike `new_v7`, the clock is not read and the monotonic counter is not
+    /// used: all 74 bits after the timestamp are random. This makes UUIDs with
+    /// known timestamps easy to produce in tests.
+    /// 
+    /// # Arguments
+    /// - `unix_ms` - Milliseconds since the Unix epoch; only the low 48 bits are
+    ///   stored
+    /// 
+    /// # Returns
+    /// - `Ok(Uuid)` - A newly generated UUID v7
+    /// - `Err(UuidError)` - If entropy collection fails
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let uuid = Uuid::new_v7_at(1_645_557_742_000).expect("Failed to generate UUID");
+    /// assert_eq!(uuid.timestamp(), Some(1_645_557_742_000));
+    /// ```
+    pub fn new_v7_at(unix_ms: u64) -> Result<Self, UuidError> {
+        let mut bytes = [0u8; 16];
+        Self::fill_random_bytes(&mut bytes)?;
+
+        let timestamp = unix_ms.to_be_bytes();
+        bytes[..6].copy_from_slice(&timestamp[2..]);
+        bytes[6] = (bytes[6] & 0x0f) | 0x70;
+        bytes[8] = (bytes[8] & 0x3f) | 0x80;
+
+        Ok(Uuid { bytes })
+    }
+    
+    /// Creates a name-based UUID v5 from a namespace and a name using SHA-1
+    /// 
+    /// The same namespace and name always produce the same UUID:
+    /// 1. Hash the 16 namespace bytes followed by the name with SHA-1
+    /// 2. Keep the first 16 bytes of the 20-byte digest
+    /// 3. Set the version field (bits 48-51) to 0b0101 (5)
+    /// 4. Set the variant field (bits 64-65) to 0b10
+    /// 
+    /// # Arguments
+    /// - `namespace` - Namespace UUID the name is scoped to
+    /// - `name` - Name to derive the UUID from
+    /// 
+    /// # Returns
+    /// The deterministic UUID v5 for `namespace` and `name`
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let dns = Uuid::parse_str("6ba7b810-9dad-11d1-80b4-00c04fd430c8").unwrap();
+    /// let uuid = Uuid::new_v5(&dns, b"python.org");
+    /// assert_eq!(uuid.to_string(), "886313e1-3b8a-5372-9b90-0c9aee199e5d");
+    /// ```
+    pub fn new_v5(namespace: &Uuid, name: &[u8]) -> Self {
+        let digest = hash::sha1(&Self::name_message(namespace, name));
+        Self::from_name_digest(&digest[..16], 5)
+    }
+
+    /// Creates a name-based UUID v3 from a namespace and a name using MD5
+    /// 
+    /// Identical to `new_v5` except that the namespace and name are hashed
+    /// with MD5 and the version field is set to 0b0011 (3). Prefer v5 for new
+    /// systems; v3 exists for compatibility with identifiers derived elsewhere.
+    /// 
+    /// # Arguments
+    /// - `namespace` - Namespace UUID the name is scoped to
+    /// - `name` - Name to derive the UUID from
+    /// 
+    /// # Returns
+    /// The deterministic UUID v3 for `namespace` and `name`
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let dns = Uuid::parse_str("6ba7b810-9dad-11d1-80b4-00c04fd430c8").unwrap();
+    /// let uuid = Uuid::new_v3(&dns, b"example.com");
+    /// assert_eq!(uuid.to_string(), "9073926b-929f-31c2-abc9-fad77ae3e8eb");
+    /// ```
+    pub fn new_v3(namespace: &Uuid, name: &[u8]) -> Self {
+        let digest = hash::md5(&Self::name_message(namespace, name));
+        Self::from_name_digest(&digest, 3)
+    }
+
+    /// Creates a custom UUID v8 from caller-supplied bytes
+    /// 
+    /// UUID v8 leaves the layout to the application. Only the fixed bits are
+    /// touched:
+    /// 1. Set the version field (bits 48-51) to 0b1000 (8)
+    /// 2. Set the variant field (bits 64-65) to 0b10
+    /// 
+    /// All other bits are copied from `data` unchanged.
+    /// 
+    /// # Arguments
+    /// - `data` - Application-specific bytes to embed
+    /// 
+    /// # Returns
+    /// The UUID v8 carrying `data`
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let uuid = Uuid::new_v8([0xff; 16]);
+    /// assert_eq!(uuid.to_string(), "ffffffff-ffff-8fff-bfff-ffffffffffff");
+    /// ```
+    pub fn new_v8(data: [u8; 16]) -> Self {
+        let mut bytes = data;
+        bytes[6] = (bytes[6] & 0x0f) | 0x80;
+        bytes[8] = (bytes[8] & 0x3f) | 0x80;
+
+        Uuid { bytes }
+    }
+
+    /// Concatenates the namespace bytes and the name into the message that
+    /// name-based UUIDs hash
+    fn name_message(namespace: &Uuid, name: &[u8]) -> Vec<u8> {
+        let mut message = Vec::with_capacity(16 + name.len());
+        message.extend_from_slice(namespace.as_bytes());
+        message.extend_from_slice(name);
+        message
+    }
+
+    /// Builds a UUID from the first 16 bytes of a digest, stamping the given
+    /// version and the RFC 4122 variant
+    fn from_name_digest(digest: &[u8], version: u8) -> Self {
+        let mut bytes = [0u8; 16];
+        bytes.copy_from_slice(&digest[..16]);
+        bytes[6] = (bytes[6] & 0x0f) | (version << 4);
+        bytes[8] = (bytes[8] & 0x3f) | 0x80;
+
+        Uuid { bytes }
+    }
+    
     /// Fills a byte array with cryptographically secure random data from system entropy
     /// 
     /// This function demonstrates how to collect entropy without external dependencies:
@@ -154,6 +645,147 @@ impl Uuid {
         }
     }
     
+    /// Parses a UUID from any of its common string forms
+    /// 
+    /// The following representations are accepted:
+    /// - Canonical (36 characters): `550e8400-e29b-41d4-a716-446655440000`
+    /// - Simple (32 characters): `550e8400e29b41d4a716446655440000`
+    /// - Braced (38 characters): `{550e8400-e29b-41d4-a716-446655440000}`
+    /// - URN (45 characters): `urn:uuid:550e8400-e29b-41d4-a716-446655440000`
+    /// 
+    /// Hyphenated forms must have hyphens at offsets 8, 13, 18, and 23 of the
+    /// canonical part and hexadecimal digits everywhere else. Both upper and
+    /// lower case hex digits are accepted.
+    /// 
+    /// # Arguments
+    /// - `s` - String in one of the accepted forms
+    /// 
+    /// # Returns
+    /// - `Ok(Uuid)` - The parsed UUID
+    /// - `Err(UuidError::InvalidFormat)` - If the string is not a valid UUID
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::Uuid;
+    /// let uuid = Uuid::parse_str("550e8400-e29b-41d4-a716-446655440000").unwrap();
+    /// assert_eq!(uuid.version(), 4);
+    /// assert_eq!(Uuid::parse_str("550e8400e29b41d4a716446655440000").unwrap(), uuid);
+    /// ```
+    pub fn parse_str(s: &str) -> Result<Self, UuidError> {
+        let (body, offset) = Self::strip_wrapper(s.as_bytes())?;
+        Self::parse_hex(body, offset, body.len() == 36)
+    }
+
+    /// Normalizes any accepted representation to its hex digits
+    /// 
+    /// Removes the braces or URN prefix, if present, and checks the overall
+    /// length. Every form is then parsed by the same `parse_hex` path.
+    /// 
+    /// # Returns
+    /// The canonical (36 character) or simple (32 character) part of `input`
+    /// and its offset within `input`, for error positions
+    fn strip_wrapper(input: &[u8]) -> Result<(&[u8], usize), UuidError> {
+        match input.len() {
+            32 | 36 => Ok((input, 0)),
+            38 => {
+                if input[0] != b'{' || input[37] != b'}' {
+                    return Err(UuidError::InvalidFormat(
+                        "braced UUID must start with '{' and end with '}'".to_string(),
+                    ));
+                }
+                Ok((&input[1..37], 1))
+            }
+            45 => {
+                if !input[..9].eq_ignore_ascii_case(b"urn:uuid:") {
+                    return Err(UuidError::InvalidFormat(
+                        "URN UUID must start with 'urn:uuid:'".to_string(),
+                    ));
+                }
+                Ok((&input[9..], 9))
+            }
+            len => Err(UuidError::InvalidFormat(format!(
+                "expected 32, 36, 38, or 45 characters, found {}",
+                len
+            ))),
+        }
+    }
+
+    /// Decodes 32 hex digits, optionally separated by hyphens in the
+    /// 8-4-4-4-12 layout, into UUID bytes
+    /// 
+    /// `offset` is the position of `input` within the original string and is
+    /// only used to report accurate positions in error messages.
+    fn parse_hex(input: &[u8], offset: usize, hyphenated: bool) -> Result<Self, UuidError> {
+        let mut bytes = [0u8; 16];
+        let mut nibbles = 0;
+        for (i, &c) in input.iter().enumerate() {
+            if hyphenated && (i == 8 || i == 13 || i == 18 || i == 23) {
+                if c != b'-' {
+                    return Err(UuidError::InvalidFormat(format!(
+                        "expected '-' at position {}, found {:?}",
+                        i + offset,
+                        c as char
+                    )));
+                }
+                continue;
+            }
+
+            let value = match c {
+                b'0'..=b'9' => c - b'0',
+                b'a'..=b'f' => c - b'a' + 10,
+                b'A'..=b'F' => c - b'A' + 10,
+                b'-' => {
+                    return Err(UuidError::InvalidFormat(format!(
+                        "unexpected '-' at position {}",
+                        i + offset
+                    )))
+                }
+                _ => {
+                    return Err(UuidError::InvalidFormat(format!(
+                        "invalid hex digit {:?} at position {}",
+                        c as char,
+                        i + offset
+                    )))
+                }
+            };
+            bytes[nibbles / 2] |= if nibbles % 2 == 0 { value << 4 } else { value };
+            nibbles += 1;
+        }
+
+        Ok(Uuid { bytes })
+    }
+    
+    /// Returns the raw timestamp embedded in time-based UUIDs
+    /// 
+    /// The unit depends on the version:
+    /// - Version 1: 60-bit count of 100-nanosecond intervals since the
+    ///   Gregorian epoch (1582-10-15 00:00:00 UTC), reassembled from the
+    ///   time_low, time_mid, and time_hi fields
+    /// - Version 6: The same 60-bit count, stored most significant bits first
+    /// - Version 7: 48-bit count of milliseconds since the Unix epoch
+    /// 
+    /// # Returns
+    /// `Some(timestamp)` for versions that carry a timestamp, `None` otherwise
+    pub fn timestamp(&self) -> Option<u64> {
+        let b = &self.bytes;
+        match self.version() {
+            1 => {
+                let time_low = u32::from_be_bytes([b[0], b[1], b[2], b[3]]) as u64;
+                let time_mid = u16::from_be_bytes([b[4], b[5]]) as u64;
+                let time_hi = (u16::from_be_bytes([b[6], b[7]]) & 0x0fff) as u64;
+                Some((time_hi << 48) | (time_mid << 32) | time_low)
+            }
+            6 => {
+                let time_high = u32::from_be_bytes([b[0], b[1], b[2], b[3]]) as u64;
+                let time_mid = u16::from_be_bytes([b[4], b[5]]) as u64;
+                let time_low = (u16::from_be_bytes([b[6], b[7]]) & 0x0fff) as u64;
+                Some((time_high << 28) | (time_mid << 12) | time_low)
+            }
+            7 => Some(u64::from_be_bytes([0, 0, b[0], b[1], b[2], b[3], b[4], b[5]])),
+            _ => None,
+        }
+    }
+    
     /// Creates a UUID from a byte array
     /// 
     /// # Arguments
@@ -166,6 +798,91 @@ impl Uuid {
     }
 }
 
+impl Uuid {
+    /// Formats the UUID in the requested string style
+    /// 
+    /// # Arguments
+    /// - `style` - The representation to produce
+    /// 
+    /// # Returns
+    /// A string of exactly `style.len()` characters
+    /// 
+    /// # Example
+    /// ```rust
+    /// # use uuid_generator::{FormatStyle, Uuid};
+    /// let uuid = Uuid::parse_str("550e8400-e29b-41d4-a716-446655440000").unwrap();
+    /// assert_eq!(uuid.to_styled_string(FormatStyle::Braced), "{550e8400-e29b-41d4-a716-446655440000}");
+    /// ```
+    pub fn to_styled_string(&self, style: FormatStyle) -> String {
+        match style {
+            FormatStyle::Canonical => self.to_string(),
+            FormatStyle::Braced => format!("{{{}}}", self),
+            FormatStyle::Simple => self.bytes.iter().map(|b| format!("{:02x}", b)).collect(),
+            FormatStyle::Urn => format!("urn:uuid:{}", self),
+            FormatStyle::CanonicalUpper => self.to_string().to_ascii_uppercase(),
+        }
+    }
+}
+
+/// Number of UUIDs worth of random data a `UuidContext` reads at a time
+const CONTEXT_POOL_UUIDS: usize = 256;
+
+/// Reusable state for generating many UUID v4s
+/// 
+/// A context buffers random data read from the entropy source, so consecutive
+/// generations only touch the source once every `CONTEXT_POOL_UUIDS` UUIDs
+/// instead of on every call. Consumed bytes are zeroed in the buffer.
+/// 
+/// # Example
+/// ```rust
+/// # use uuid_generator::UuidContext;
+/// let mut ctx = UuidContext::new();
+/// let uuid = ctx.new_v4().expect("Failed to generate UUID");
+/// assert_eq!(uuid.version(), 4);
+/// ```
+pub struct UuidContext {
+    pool: Vec<u8>,
+    next: usize,
+}
+
+impl UuidContext {
+    /// Creates a context with an empty buffer, filled on first use
+    pub fn new() -> Self {
+        let pool = vec![0u8; CONTEXT_POOL_UUIDS * 16];
+        let next = pool.len();
+        UuidContext { pool, next }
+    }
+
+    /// Creates a new random UUID v4 from the buffered random data
+    /// 
+    /// # Returns
+    /// - `Ok(Uuid)` - A newly generated UUID v4
+    /// - `Err(UuidError)` - If the buffer needed refilling and entropy collection failed
+    pub fn new_v4(&mut self) -> Result<Uuid, UuidError> {
+        if self.next == self.pool.len() {
+            Uuid::fill_random_bytes(&mut self.pool)?;
+            self.next = 0;
+        }
+
+        let chunk = &mut self.pool[self.next..self.next + 16];
+        let mut bytes = [0u8; 16];
+        bytes.copy_from_slice(chunk);
+        chunk.fill(0);
+        self.next += 16;
+
+        bytes[6] = (bytes[6] & 0x0f) | 0x40;
+        bytes[8] = (bytes[8] & 0x3f) | 0x80;
+
+        Ok(Uuid { bytes })
+    }
+}
+
+impl Default for UuidContext {
+    fn default() -> Self {
+        Self::new()
+    }
+}
+
 impl fmt::Display for Uuid {
     /// Formats the UUID in the standard 8-4-4-4-12 hexadecimal string representation
     /// 
@@ -240,6 +957,320 @@ mod tests {
         assert_eq!(uuid.version(), 4); // Version extracted from byte 6
     }
     
+    #[test]
+    fn test_uuid_v4_batch_generation() {
+        let uuids = Uuid::new_v4_batch(1000).expect("Should generate UUIDs successfully");
+        assert_eq!(uuids.len(), 1000);
+        
+        for uuid in uuids.iter() {
+            assert_eq!(uuid.version(), 4);
+            assert_eq!(uuid.variant(), 2);
+        }
+        
+        let unique: std::collections::HashSet<_> = uuids.iter().collect();
+        assert_eq!(unique.len(), uuids.len(), "Batch UUIDs should be unique");
+        
+        assert!(Uuid::new_v4_batch(0).expect("Empty batch should succeed").is_empty());
+    }
+    
+    #[test]
+    fn test_uuid_v7_generation() {
+        let uuid = Uuid::new_v7().expect("Should generate UUID successfully");
+        
+        assert_eq!(uuid.version(), 7, "UUID version should be 7");
+        assert_eq!(uuid.variant(), 2, "UUID variant should be 2 (RFC 4122)");
+        
+        // Timestamp should be close to the current time. The shared V7 state
+        // may have run slightly ahead of the clock, so allow either direction.
+        let now_ms = SystemTime::now().duration_since(UNIX_EPOCH).unwrap().as_millis() as u64;
+        let mut timestamp = [0u8; 8];
+        timestamp[2..].copy_from_slice(&uuid.as_bytes()[..6]);
+        let unix_ms = u64::from_be_bytes(timestamp);
+        assert!(unix_ms.abs_diff(now_ms) < 1000);
+    }
+    
+    #[test]
+    fn test_uuid_v7_at() {
+        let unix_ms = 1_645_557_742_000;
+        let first = Uuid::new_v7_at(unix_ms).expect("Should generate UUID successfully");
+        let second = Uuid::new_v7_at(unix_ms).expect("Should generate UUID successfully");
+        
+        assert_eq!(first.version(), 7, "UUID version should be 7");
+        assert_eq!(first.variant(), 2, "UUID variant should be 2 (RFC 4122)");
+        assert_eq!(first.timestamp(), Some(unix_ms));
+        assert_eq!(&first.as_bytes()[..6], &second.as_bytes()[..6]);
+        assert_ne!(first, second);
+    }
+    
+    #[test]
+    fn test_uuid_v7_counter_is_strictly_monotonic() {
+        let first = Uuid::new_v7().expect("Should generate UUID successfully");
+        let second = Uuid::new_v7().expect("Should generate UUID successfully");
+        
+        let counter = |uuid: &Uuid| u16::from_be_bytes([uuid.as_bytes()[6], uuid.as_bytes()[7]]) & V7_COUNTER_MAX;
+        assert!(second.as_bytes() > first.as_bytes());
+        if first.as_bytes()[..6] == second.as_bytes()[..6] {
+            assert_eq!(counter(&second), counter(&first) + 1);
+        }
+    }
+    
+    #[test]
+    fn test_uuid_v7_concurrent_generation() {
+        let handles: Vec<_> = (0..8)
+            .map(|_| {
+                std::thread::spawn(|| {
+                    (0..2000)
+                        .map(|_| Uuid::new_v7().expect("Should generate UUID successfully"))
+                        .collect::<Vec<_>>()
+                })
+            })
+            .collect();
+        
+        let mut all = Vec::new();
+        for handle in handles {
+            let uuids = handle.join().unwrap();
+            for pair in uuids.windows(2) {
+                assert!(pair[1].as_bytes() > pair[0].as_bytes(), "{} did not follow {}", pair[1], pair[0]);
+            }
+            all.extend(uuids);
+        }
+        
+        // Every value handed out across threads is unique
+        let total = all.len();
+        all.sort_by(|a, b| a.as_bytes().cmp(b.as_bytes()));
+        all.dedup();
+        assert_eq!(all.len(), total);
+    }
+    
+    #[test]
+    fn test_uuid_parse_str() {
+        let uuid = Uuid::parse_str("550e8400-e29b-41d4-a716-446655440000").expect("Should parse");
+        assert_eq!(uuid.as_bytes(), &[0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4,
+                                      0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00]);
+        assert_eq!(format!("{}", uuid), "550e8400-e29b-41d4-a716-446655440000");
+        
+        // Round trip a generated UUID
+        let generated = Uuid::new_v4().expect("Should generate UUID");
+        assert_eq!(Uuid::parse_str(&generated.to_string()), Ok(generated));
+    }
+    
+    #[test]
+    fn test_uuid_parse_str_accepted_forms() {
+        let expected = Uuid::parse_str("550e8400-e29b-41d4-a716-446655440000").unwrap();
+        let inputs = [
+            "550e8400-e29b-41d4-a716-446655440000",
+            "550E8400-E29B-41D4-A716-446655440000",
+            "550e8400e29b41d4a716446655440000",
+            "550E8400E29B41D4A716446655440000",
+            "{550e8400-e29b-41d4-a716-446655440000}",
+            "{550E8400-E29B-41D4-A716-446655440000}",
+            "urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+            "URN:UUID:550e8400-e29b-41d4-a716-446655440000",
+        ];
+        
+        for input in inputs.iter() {
+            assert_eq!(Uuid::parse_str(input), Ok(expected), "input {:?}", input);
+        }
+        
+        // Every output style parses back to the same UUID
+        let generated = Uuid::new_v4().expect("Should generate UUID");
+        for style in [FormatStyle::Canonical, FormatStyle::Braced, FormatStyle::Simple, FormatStyle::Urn, FormatStyle::CanonicalUpper].iter() {
+            assert_eq!(Uuid::parse_str(&generated.to_styled_string(*style)), Ok(generated));
+        }
+    }
+    
+    #[test]
+    fn test_uuid_parse_str_rejects_malformed() {
+        let cases = [
+            ("", "expected 32, 36, 38, or 45 characters, found 0"),
+            ("550e8400-e29b-41d4-a716-44665544000", "expected 32, 36, 38, or 45 characters, found 35"),
+            ("550e8400-e29b-41d4-a716-4466554400000", "expected 32, 36, 38, or 45 characters, found 37"),
+            ("550e8400e29b41d4a71644665544000", "expected 32, 36, 38, or 45 characters, found 31"),
+            ("550e8400e29b41d4a716446655440000a", "expected 32, 36, 38, or 45 characters, found 33"),
+            ("550e8400e29b41d4-716446655440000", "unexpected '-' at position 16"),
+            ("550e8400e29b41d4a71644665544000x", "invalid hex digit 'x' at position 31"),
+            ("{550e8400-e29b-41d4-a716-446655440000]", "braced UUID must start with '{' and end with '}'"),
+            ("{550e8400-e29b-41d4-a716_446655440000}", "expected '-' at position 24, found '_'"),
+            ("uri:uuid:550e8400-e29b-41d4-a716-446655440000", "URN UUID must start with 'urn:uuid:'"),
+            ("urn:uuid:550e8400e29b41d4a716446655440000", "expected 32, 36, 38, or 45 characters, found 41"),
+            ("550e8400-e29b-41d4-a716-44665544000g", "invalid hex digit 'g' at position 35"),
+            ("550e8400-e29b-41d4-a716-4466 5440000", "invalid hex digit ' ' at position 28"),
+            ("550e840-0e29b-41d4-a716-446655440000", "unexpected '-' at position 7"),
+            ("550e8400-e29b-41d-4a716-446655440000", "unexpected '-' at position 17"),
+            ("550e8400-e29b-41d4a-716-446655440000", "expected '-' at position 18, found 'a'"),
+            ("550e8400_e29b_41d4_a716_446655440000", "expected '-' at position 8, found '_'"),
+            ("550e8400-e29b-41d4-a716-44665544000é", "expected 32, 36, 38, or 45 characters, found 37"),
+        ];
+        
+        for (input, message) in cases.iter() {
+            assert_eq!(
+                Uuid::parse_str(input),
+                Err(UuidError::InvalidFormat(message.to_string())),
+                "input {:?}",
+                input
+            );
+        }
+    }
+    
+    #[test]
+    fn test_uuid_to_styled_string() {
+        let uuid = Uuid::parse_str("550e8400-e29b-41d4-a716-446655440000").expect("Should parse");
+        
+        assert_eq!(uuid.to_styled_string(FormatStyle::Canonical), "550e8400-e29b-41d4-a716-446655440000");
+        assert_eq!(uuid.to_styled_string(FormatStyle::Braced), "{550e8400-e29b-41d4-a716-446655440000}");
+        assert_eq!(uuid.to_styled_string(FormatStyle::Simple), "550e8400e29b41d4a716446655440000");
+        assert_eq!(uuid.to_styled_string(FormatStyle::Urn), "urn:uuid:550e8400-e29b-41d4-a716-446655440000");
+        assert_eq!(uuid.to_styled_string(FormatStyle::CanonicalUpper), "550E8400-E29B-41D4-A716-446655440000");
+        
+        for style in [FormatStyle::Canonical, FormatStyle::Braced, FormatStyle::Simple, FormatStyle::Urn, FormatStyle::CanonicalUpper].iter() {
+            assert_eq!(uuid.to_styled_string(*style).len(), style.len());
+        }
+    }
+    
+    #[test]
+    fn test_uuid_v5_generation() {
+        let dns = Uuid::parse_str("6ba7b810-9dad-11d1-80b4-00c04fd430c8").unwrap();
+        
+        let uuid = Uuid::new_v5(&dns, b"www.example.com");
+        assert_eq!(uuid.to_string(), "2ed6657d-e927-568b-95e1-2665a8aea6a2");
+        assert_eq!(uuid.version(), 5, "UUID version should be 5");
+        assert_eq!(uuid.variant(), 2, "UUID variant should be 2 (RFC 4122)");
+        
+        // Same inputs are deterministic, different names differ
+        assert_eq!(Uuid::new_v5(&dns, b"www.example.com"), uuid);
+        assert_ne!(Uuid::new_v5(&dns, b"example.com"), uuid);
+    }
+    
+    #[test]
+    fn test_uuid_v3_generation() {
+        let dns = Uuid::parse_str("6ba7b810-9dad-11d1-80b4-00c04fd430c8").unwrap();
+        
+        // Test vectors produced by Python's uuid.uuid3
+        assert_eq!(Uuid::new_v3(&dns, b"example.com").to_string(), "9073926b-929f-31c2-abc9-fad77ae3e8eb");
+        assert_eq!(Uuid::new_v3(&dns, b"python.org").to_string(), "6fa459ea-ee8a-3ca4-894e-db77e160355e");
+        assert_eq!(Uuid::new_v3(&dns, b"www.example.com").to_string(), "5df41881-3aed-3515-88a7-2f4a814cf09e");
+        
+        let uuid = Uuid::new_v3(&dns, b"example.com");
+        assert_eq!(uuid.version(), 3, "UUID version should be 3");
+        assert_eq!(uuid.variant(), 2, "UUID variant should be 2 (RFC 4122)");
+        assert_eq!(Uuid::new_v3(&dns, b"example.com"), uuid);
+    }
+    
+    #[test]
+    fn test_uuid_v8_generation() {
+        let data: [u8; 16] = [
+            0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77,
+            0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff,
+        ];
+        let uuid = Uuid::new_v8(data);
+        assert_eq!(uuid.to_string(), "00112233-4455-8677-8899-aabbccddeeff");
+        assert_eq!(uuid.version(), 8, "UUID version should be 8");
+        assert_eq!(uuid.variant(), 2, "UUID variant should be 2 (RFC 4122)");
+        
+        // Only the version nibble and the variant bits may change
+        for (i, (&got, &want)) in uuid.as_bytes().iter().zip(data.iter()).enumerate() {
+            let mask = match i {
+                6 => 0x0f,
+                8 => 0x3f,
+                _ => 0xff,
+            };
+            assert_eq!(got & mask, want & mask, "byte {} changed", i);
+        }
+    }
+    
+    #[test]
+    fn test_uuid_v1_generation() {
+        let first = Uuid::new_v1().expect("Should generate UUID successfully");
+        let second = Uuid::new_v1().expect("Should generate UUID successfully");
+        
+        assert_eq!(first.version(), 1, "UUID version should be 1");
+        assert_eq!(first.variant(), 2, "UUID variant should be 2 (RFC 4122)");
+        
+        // Random nodes must have the multicast bit set
+        assert_eq!(first.as_bytes()[10] & 0x01, 0x01);
+        assert_eq!(&first.as_bytes()[8..], &second.as_bytes()[8..]);
+        
+        assert!(second.timestamp().unwrap() > first.timestamp().unwrap());
+        
+        let now = SystemTime::now().duration_since(UNIX_EPOCH).unwrap().as_nanos() as u64 / 100;
+        let unix_ticks = second.timestamp().unwrap() - GREGORIAN_OFFSET;
+        assert!(now >= unix_ticks && now - unix_ticks < 10_000_000);
+    }
+    
+    #[test]
+    fn test_uuid_v1_with_node() {
+        let node = [0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e];
+        let uuid = Uuid::new_v1_with_node(node).expect("Should generate UUID successfully");
+        
+        assert_eq!(&uuid.as_bytes()[10..], &node);
+        assert_eq!(uuid.version(), 1);
+        assert_eq!(uuid.variant(), 2);
+    }
+    
+    #[test]
+    fn test_uuid_v2_generation() {
+        let uuid = Uuid::new_v2(1, 0xdead_beef).expect("Should generate UUID successfully");
+        
+        assert_eq!(uuid.version(), 2, "UUID version should be 2");
+        assert_eq!(uuid.variant(), 2, "UUID variant should be 2 (RFC 4122)");
+        assert_eq!(&uuid.as_bytes()[..4], &[0xde, 0xad, 0xbe, 0xef]);
+        assert_eq!(uuid.as_bytes()[9], 1);
+    }
+    
+    #[test]
+    fn test_uuid_context_refills_pool() {
+        let mut ctx = UuidContext::new();
+        let mut seen = std::collections::HashSet::new();
+        
+        // Run past the end of the pool to exercise a refill
+        for _ in 0..CONTEXT_POOL_UUIDS * 2 + 1 {
+            let uuid = ctx.new_v4().expect("Should generate UUID successfully");
+            assert_eq!(uuid.version(), 4, "UUID version should be 4");
+            assert_eq!(uuid.variant(), 2, "UUID variant should be 2 (RFC 4122)");
+            assert!(seen.insert(uuid), "UUIDs should be unique");
+        }
+    }
+    
+    #[test]
+    fn test_uuid_from_v1_fields() {
+        let uuid = Uuid::from_v1_fields(138648505420000000, 0x1234, [1, 2, 3, 4, 5, 6]);
+        assert_eq!(uuid.to_string(), "c232ab00-9414-11ec-9234-010203040506");
+    }
+    
+    #[test]
+    fn test_uuid_v6_generation() {
+        let first = Uuid::new_v6().expect("Should generate UUID successfully");
+        let second = Uuid::new_v6().expect("Should generate UUID successfully");
+        
+        assert_eq!(first.version(), 6, "UUID version should be 6");
+        assert_eq!(first.variant(), 2, "UUID variant should be 2 (RFC 4122)");
+        assert!(second.timestamp().unwrap() > first.timestamp().unwrap());
+        assert!(second.to_string() > first.to_string());
+    }
+    
+    #[test]
+    fn test_uuid_from_v6_fields() {
+        // Same timestamp, clock sequence, and node as the v1 example above
+        let uuid = Uuid::from_v6_fields(138648505420000000, 0x1234, [1, 2, 3, 4, 5, 6]);
+        assert_eq!(uuid.to_string(), "1ec9414c-232a-6b00-9234-010203040506");
+        assert_eq!(uuid.timestamp(), Some(138648505420000000));
+    }
+    
+    #[test]
+    fn test_uuid_timestamp() {
+        // 2022-02-22 19:22:22 UTC as a version 1 UUID
+        let v1 = Uuid::parse_str("c232ab00-9414-11ec-9234-010203040506").unwrap();
+        assert_eq!(v1.timestamp(), Some(138648505420000000));
+        
+        let now_ms = SystemTime::now().duration_since(UNIX_EPOCH).unwrap().as_millis() as u64;
+        let v7 = Uuid::new_v7().expect("Should generate UUID");
+        let unix_ms = v7.timestamp().expect("v7 should carry a timestamp");
+        assert!(unix_ms >= now_ms && unix_ms - now_ms < 1000);
+        
+        let v4 = Uuid::new_v4().expect("Should generate UUID");
+        assert_eq!(v4.timestamp(), None);
+    }
+    
     #[test]
     fn test_multiple_generations() {
         // Generate multiple UUIDs to test consistency